@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 )
 
 func (client *KMIRestClient) CreateDefinition(collectionName string, definitionName string, definition KMIDefinition) error {
@@ -92,3 +93,50 @@ func (client *KMIRestClient) GetDefinition(collectionName string, definitionName
 	}
 	return &responseDetails, nil
 }
+
+// DeleteDefinitionOption removes a single option, such as signacl:<collection>, from a definition.
+func (client *KMIRestClient) DeleteDefinitionOption(collectionName string, definitionName string, optionName string) error {
+	idenityengineurl := fmt.Sprintf("%s/definition/Col=%s/Def=%s/Opt=%s", client.Host, collectionName, definitionName, url.PathEscape(optionName))
+
+	req, err := http.NewRequest("DELETE", idenityengineurl, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.httpclient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("error while calling DeleteDefinitionOption api  %s and payload is %v", resp.Status, resp)
+	}
+	return nil
+}
+
+// GetSecret returns the secret stored at the given index of a definition.
+func (client *KMIRestClient) GetSecret(collectionName string, definitionName string, index string) (*KMISecretResponse, error) {
+	idenityengineurl := fmt.Sprintf("%s/secret/Col=%s/Def=%s/Idx=%s", client.Host, collectionName, definitionName, index)
+
+	resp, err := client.httpclient.Get(idenityengineurl)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error while calling GetSecret api  %s and payload is %v", resp.Status, resp)
+	}
+
+	responseData, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var responseDetails KMISecretResponse
+	err = xml.Unmarshal(responseData, &responseDetails)
+	if err != nil {
+		return nil, err
+	}
+	return &responseDetails, nil
+}
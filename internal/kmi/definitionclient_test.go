@@ -0,0 +1,78 @@
+package kmi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeleteDefinitionOption(t *testing.T) {
+	var method, path string
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method = r.Method
+		path = r.URL.EscapedPath()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer svr.Close()
+	client := &KMIRestClient{
+		Host:       svr.URL,
+		httpclient: svr.Client(),
+	}
+
+	err := client.DeleteDefinitionOption("ca", "root", "signacldomain:*.example.com")
+	assert.NoError(t, err, "Expected no error")
+	assert.Equal(t, http.MethodDelete, method)
+	assert.Equal(t, "/definition/Col=ca/Def=root/Opt=signacldomain:%2A.example.com", path)
+}
+
+func TestDeleteDefinitionOptionError(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer svr.Close()
+	client := &KMIRestClient{
+		Host:       svr.URL,
+		httpclient: svr.Client(),
+	}
+
+	err := client.DeleteDefinitionOption("ca", "root", "signacl:web")
+	assert.Error(t, err, "Expected an error")
+}
+
+func TestGetSecret(t *testing.T) {
+	var path string
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`<secret index="3" status="active"><block name="cert">-----BEGIN CERTIFICATE-----</block><block name="key" b64encoded="True">a2V5</block></secret>`))
+	}))
+	defer svr.Close()
+	client := &KMIRestClient{
+		Host:       svr.URL,
+		httpclient: svr.Client(),
+	}
+
+	secret, err := client.GetSecret("col", "cert", "3")
+	assert.NoError(t, err, "Expected no error")
+	assert.Equal(t, "/secret/Col=col/Def=cert/Idx=3", path)
+	assert.Equal(t, "3", secret.Index)
+	assert.Len(t, secret.Block, 2)
+	assert.Equal(t, "cert", secret.Block[0].Name)
+	assert.Equal(t, "True", secret.Block[1].B64Encoded)
+}
+
+func TestGetSecretError(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer svr.Close()
+	client := &KMIRestClient{
+		Host:       svr.URL,
+		httpclient: svr.Client(),
+	}
+
+	_, err := client.GetSecret("col", "cert", "3")
+	assert.Error(t, err, "Expected an error")
+}
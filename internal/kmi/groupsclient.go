@@ -9,12 +9,12 @@ import (
 	"net/http/httputil"
 )
 
-func (client *KMIRestClient) CreateGroup(account string, groupName string) error {
+func (client *KMIRestClient) CreateGroup(account string, groupName string, group GroupRequest) error {
 	idenityengineurl := fmt.Sprintf("%s/group/Acct=%s/Name=%s", client.Host, account, groupName)
 
-	group := GroupRequest{
-		Account: account,
-		Type:    "union",
+	group.Account = account
+	if group.Type == "" {
+		group.Type = "union"
 	}
 	out, err := xml.MarshalIndent(group, " ", "  ")
 	if err != nil {
@@ -79,6 +79,55 @@ func (client *KMIRestClient) GetGroup(groupName string) (*KMIGroup, error) {
 	return &kmiGroup, nil
 }
 
+// GroupExists reports whether KMI knows a group of the given name.
+func (client *KMIRestClient) GroupExists(groupName string) (bool, error) {
+	idenityengineurl := fmt.Sprintf("%s/group/Name=%s", client.Host, groupName)
+	resp, err := client.httpclient.Get(idenityengineurl)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	}
+	return false, fmt.Errorf("error while calling GroupExists api  %s and payload is %v", resp.Status, resp)
+}
+
+// GetGroupMembers returns the names of the direct members of a group.
+func (client *KMIRestClient) GetGroupMembers(groupName string) ([]string, error) {
+	idenityengineurl := fmt.Sprintf("%s/group_membership/Parent=%s", client.Host, groupName)
+	resp, err := client.httpclient.Get(idenityengineurl)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error while calling GetGroupMembers api  %s and payload is %v", resp.Status, resp)
+	}
+
+	responseData, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var memberships KMIGroupMemberships
+	err = xml.Unmarshal(responseData, &memberships)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]string, 0, len(memberships.Memberships))
+	for _, membership := range memberships.Memberships {
+		members = append(members, membership.Child)
+	}
+	return members, nil
+}
+
 func (client *KMIRestClient) DeleteGroup(groupName string) error {
 
 	idenityengineurl := fmt.Sprintf("%s/group/Name=%s", client.Host, groupName)
@@ -109,8 +158,10 @@ func (client *KMIRestClient) DeleteGroupMembership(groupName string, child strin
 }
 
 type GroupRequest struct {
-	XMLName xml.Name `xml:"group"`
-	Text    string   `xml:",chardata"`
-	Type    string   `xml:"type,attr"`
-	Account string   `xml:"account,attr"`
+	XMLName   xml.Name `xml:"group"`
+	Text      string   `xml:",chardata"`
+	Type      string   `xml:"type,attr"`
+	Account   string   `xml:"account,attr"`
+	Adders    string   `xml:"adders,omitempty"`
+	Modifiers string   `xml:"modifiers,omitempty"`
 }
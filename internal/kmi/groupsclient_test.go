@@ -0,0 +1,160 @@
+package kmi
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateGroup(t *testing.T) {
+	var method, path string
+	var body GroupRequest
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, path = r.Method, r.URL.Path
+		data, _ := io.ReadAll(r.Body)
+		_ = xml.Unmarshal(data, &body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer svr.Close()
+	client := &KMIRestClient{
+		Host:       svr.URL,
+		httpclient: svr.Client(),
+	}
+
+	err := client.CreateGroup("PIM_TEST", "PIM_ADMIN", GroupRequest{
+		Type:      "intersection",
+		Adders:    "superusers",
+		Modifiers: "PIM_OWNERS",
+	})
+
+	assert.NoError(t, err, "Expected no error")
+	assert.Equal(t, http.MethodPost, method)
+	assert.Equal(t, "/group/Acct=PIM_TEST/Name=PIM_ADMIN", path)
+	assert.Equal(t, "intersection", body.Type)
+	assert.Equal(t, "PIM_TEST", body.Account)
+	assert.Equal(t, "superusers", body.Adders)
+	assert.Equal(t, "PIM_OWNERS", body.Modifiers)
+}
+
+func TestCreateGroupDefaultsToUnion(t *testing.T) {
+	var body GroupRequest
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		_ = xml.Unmarshal(data, &body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer svr.Close()
+	client := &KMIRestClient{
+		Host:       svr.URL,
+		httpclient: svr.Client(),
+	}
+
+	err := client.CreateGroup("PIM_TEST", "PIM_ADMIN", GroupRequest{})
+
+	assert.NoError(t, err, "Expected no error")
+	assert.Equal(t, "union", body.Type)
+	assert.Empty(t, body.Adders)
+}
+
+func TestCreateGroupError(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer svr.Close()
+	client := &KMIRestClient{
+		Host:       svr.URL,
+		httpclient: svr.Client(),
+	}
+
+	err := client.CreateGroup("PIM_TEST", "PIM_ADMIN", GroupRequest{Type: "union"})
+
+	assert.Error(t, err, "Expected an error")
+}
+
+func TestGetGroupMembers(t *testing.T) {
+	expected := `<group_memberships>
+	<group_membership parent="PIM_ADMIN" child="user:hachandr" source="restserv:user:hachandr_kmi_cert"/>
+	<group_membership parent="PIM_ADMIN" child="PIM_OWNERS" source="restserv:user:hachandr_kmi_cert"/>
+</group_memberships>`
+	var path string
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		fmt.Fprint(w, expected)
+	}))
+	defer svr.Close()
+	client := &KMIRestClient{
+		Host:       svr.URL,
+		httpclient: svr.Client(),
+	}
+
+	members, err := client.GetGroupMembers("PIM_ADMIN")
+
+	assert.NoError(t, err, "Expected no error")
+	assert.Equal(t, "/group_membership/Parent=PIM_ADMIN", path)
+	assert.Equal(t, []string{"user:hachandr", "PIM_OWNERS"}, members)
+}
+
+func TestGetGroupMembersEmpty(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<group_memberships/>`)
+	}))
+	defer svr.Close()
+	client := &KMIRestClient{
+		Host:       svr.URL,
+		httpclient: svr.Client(),
+	}
+
+	members, err := client.GetGroupMembers("PIM_ADMIN")
+
+	assert.NoError(t, err, "Expected no error")
+	assert.Empty(t, members)
+}
+
+func TestGetGroupMembersError(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer svr.Close()
+	client := &KMIRestClient{
+		Host:       svr.URL,
+		httpclient: svr.Client(),
+	}
+
+	_, err := client.GetGroupMembers("PIM_ADMIN")
+
+	assert.Error(t, err, "Expected an error")
+}
+
+func TestGroupExists(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/group/Name=PIM_ADMIN":
+			fmt.Fprint(w, `<group name="PIM_ADMIN" type="union" account="PIM_TEST"/>`)
+		case "/group/Name=PIM_MISSING":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer svr.Close()
+	client := &KMIRestClient{
+		Host:       svr.URL,
+		httpclient: svr.Client(),
+	}
+
+	exists, err := client.GroupExists("PIM_ADMIN")
+	assert.NoError(t, err, "Expected no error")
+	assert.True(t, exists)
+
+	exists, err = client.GroupExists("PIM_MISSING")
+	assert.NoError(t, err, "Expected no error")
+	assert.False(t, exists)
+
+	_, err = client.GroupExists("PIM_BROKEN")
+	assert.Error(t, err, "Expected an error")
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 )
 
 func (client *KMIRestClient) CreateTemplateOrSign(cacollectionName string, cadefinitionName string, templateName string, options Template) error {
@@ -37,7 +38,7 @@ func (client *KMIRestClient) CreateTemplateOrSign(cacollectionName string, cadef
 	return nil
 }
 
-func (client *KMIRestClient) GetTemplate(cacollectionName string, cadefinitionName string, templateName string) (*Template, error) {
+func (client *KMIRestClient) GetTemplate(cacollectionName string, cadefinitionName string, templateName string) (*TemplateResponse, error) {
 	idenityengineurl := fmt.Sprintf("%s/template/Col=%s/Def=%s/Tmpl=%s", client.Host, cacollectionName, cadefinitionName, templateName)
 
 	resp, err := client.httpclient.Get(idenityengineurl)
@@ -57,7 +58,7 @@ func (client *KMIRestClient) GetTemplate(cacollectionName string, cadefinitionNa
 		return nil, err
 	}
 
-	var responseDetails Template
+	var responseDetails TemplateResponse
 	err = xml.Unmarshal(responseData, &responseDetails)
 	if err != nil {
 		return nil, err
@@ -81,3 +82,76 @@ func (client *KMIRestClient) DeleteTemplate(cacollectionName string, cadefinitio
 	defer resp.Body.Close()
 	return nil
 }
+
+// DeleteTemplateCollectionACL revokes the approval of a client collection to sign
+// requests with the template.
+func (client *KMIRestClient) DeleteTemplateCollectionACL(cacollectionName string, cadefinitionName string, templateName string, clientCollectionName string) error {
+	idenityengineurl := fmt.Sprintf("%s/template/Col=%s/Def=%s/Tmpl=%s/CollectionACL=%s", client.Host, cacollectionName, cadefinitionName, templateName, url.PathEscape(clientCollectionName))
+
+	req, err := http.NewRequest("DELETE", idenityengineurl, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.httpclient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("error while calling DeleteTemplateCollectionACL api  %s and payload is %v", resp.Status, resp)
+	}
+	return nil
+}
+
+// DeleteTemplateConstraint removes a constraint from the template, restoring the
+// KMI default for that constraint type.
+func (client *KMIRestClient) DeleteTemplateConstraint(cacollectionName string, cadefinitionName string, templateName string, constraintType string) error {
+	idenityengineurl := fmt.Sprintf("%s/template/Col=%s/Def=%s/Tmpl=%s/Constraint=%s", client.Host, cacollectionName, cadefinitionName, templateName, url.PathEscape(constraintType))
+
+	req, err := http.NewRequest("DELETE", idenityengineurl, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.httpclient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("error while calling DeleteTemplateConstraint api  %s and payload is %v", resp.Status, resp)
+	}
+	return nil
+}
+
+// SignCertificateRequest signs a PEM encoded certificate signing request with the
+// CA definition according to the template, returning the signed certificate.
+func (client *KMIRestClient) SignCertificateRequest(cacollectionName string, cadefinitionName string, templateName string, request SignRequest) (*KMISecretResponse, error) {
+	idenityengineurl := fmt.Sprintf("%s/template/Col=%s/Def=%s/Tmpl=%s", client.Host, cacollectionName, cadefinitionName, templateName)
+	out, err := xml.MarshalIndent(request, "", "")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.httpclient.Post(idenityengineurl, "application/xml", bytes.NewBuffer(out))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	responseData, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error while calling SignCertificateRequest api  %s and payload is %v", resp.Status, string(responseData))
+	}
+
+	var responseDetails KMISecretResponse
+	err = xml.Unmarshal(responseData, &responseDetails)
+	if err != nil {
+		return nil, err
+	}
+	return &responseDetails, nil
+}
@@ -3,16 +3,22 @@ package kmi
 import (
 	"encoding/xml"
 	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
 
 	"log"
 	"reflect"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func Test_TemplateMarshalling(t *testing.T) {
 	data := []byte(`<template name="cpc_client_template" source="restserv:user:hachandr_kmi_cert" add_date="2023-12-20 17:16:27" modified="354687073">
 	<constraint type="common_name" warn="False" source="restserv:user:hachandr_kmi_cert" add_date="2023-12-20 17:16:27" modified="354687072">instance-validator</constraint>
 	<collectionacl target="PIM_SECRETS" source="restserv:user:hachandr_kmi_cert" add_date="2023-12-20 17:16:28" modified="354687073"/>
+	<collectionacl target="web_certs" source="restserv:user:hachandr_kmi_cert" add_date="2023-12-21 10:02:11" modified="354687080"/>
   </template>`)
 	var e1 TemplateResponse
 	err := xml.Unmarshal(data, &e1)
@@ -32,11 +38,14 @@ func Test_TemplateMarshalling(t *testing.T) {
 		t.Errorf("Marshalling() = %v, want %v", e1.Name, "2023-12-20 17:16:27")
 	}
 
-	if !reflect.DeepEqual(e1.Collectionacl.Target, "PIM_SECRETS") {
-		t.Errorf("Marshalling() = %v, want %v", e1.Collectionacl.Target, "PIM_SECRETS")
+	if !reflect.DeepEqual(e1.Collectionacl[0].Target, "PIM_SECRETS") {
+		t.Errorf("Marshalling() = %v, want %v", e1.Collectionacl[0].Target, "PIM_SECRETS")
+	}
+	if !reflect.DeepEqual(e1.Collectionacl[1].Target, "web_certs") {
+		t.Errorf("Marshalling() = %v, want %v", e1.Collectionacl[1].Target, "web_certs")
 	}
 	if !reflect.DeepEqual(e1.Constraints[0].Text, "instance-validator") {
-		t.Errorf("Marshalling() = %v, want %v", e1.Collectionacl.Target, "instance-validator")
+		t.Errorf("Marshalling() = %v, want %v", e1.Constraints[0].Text, "instance-validator")
 	}
 
 }
@@ -57,3 +66,92 @@ func Test_SigningMarshalling(t *testing.T) {
 		t.Errorf("Marshalling() = %v, want %v", out, data)
 	}
 }
+
+func TestDeleteTemplateCollectionACL(t *testing.T) {
+	var method, path string
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method = r.Method
+		path = r.URL.Path
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer svr.Close()
+	client := &KMIRestClient{
+		Host:       svr.URL,
+		httpclient: svr.Client(),
+	}
+
+	err := client.DeleteTemplateCollectionACL("ca", "root", "web", "web_certs")
+	assert.NoError(t, err, "Expected no error")
+	assert.Equal(t, http.MethodDelete, method)
+	assert.Equal(t, "/template/Col=ca/Def=root/Tmpl=web/CollectionACL=web_certs", path)
+}
+
+func TestDeleteTemplateConstraint(t *testing.T) {
+	var method, path string
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method = r.Method
+		path = r.URL.Path
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer svr.Close()
+	client := &KMIRestClient{
+		Host:       svr.URL,
+		httpclient: svr.Client(),
+	}
+
+	err := client.DeleteTemplateConstraint("ca", "root", "web", "ip_san")
+	assert.NoError(t, err, "Expected no error")
+	assert.Equal(t, http.MethodDelete, method)
+	assert.Equal(t, "/template/Col=ca/Def=root/Tmpl=web/Constraint=ip_san", path)
+}
+
+func TestDeleteTemplateConstraintError(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer svr.Close()
+	client := &KMIRestClient{
+		Host:       svr.URL,
+		httpclient: svr.Client(),
+	}
+
+	err := client.DeleteTemplateConstraint("ca", "root", "web", "ip_san")
+	assert.Error(t, err, "Expected an error")
+}
+
+func TestSignCertificateRequest(t *testing.T) {
+	var path, body string
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`<secret index="1"><block name="cert">-----BEGIN CERTIFICATE-----</block></secret>`))
+	}))
+	defer svr.Close()
+	client := &KMIRestClient{
+		Host:       svr.URL,
+		httpclient: svr.Client(),
+	}
+
+	secret, err := client.SignCertificateRequest("ca", "root", "web", SignRequest{CSR: "csr", TTL: "30d"})
+	assert.NoError(t, err, "Expected no error")
+	assert.Equal(t, "/template/Col=ca/Def=root/Tmpl=web", path)
+	assert.Equal(t, `<sign><csr>csr</csr><ttl>30d</ttl></sign>`, body)
+	assert.Equal(t, "cert", secret.Block[0].Name)
+}
+
+func TestSignCertificateRequestError(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("dns_san not allowed"))
+	}))
+	defer svr.Close()
+	client := &KMIRestClient{
+		Host:       svr.URL,
+		httpclient: svr.Client(),
+	}
+
+	_, err := client.SignCertificateRequest("ca", "root", "web", SignRequest{CSR: "csr"})
+	assert.ErrorContains(t, err, "dns_san not allowed")
+}
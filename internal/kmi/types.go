@@ -111,8 +111,16 @@ type Workload struct {
 		Text   string `xml:",chardata"`
 		Source string `xml:"source,attr,omitempty"`
 	} `xml:"region"`
-	KubernetesServiceAccount *K8ServiceAccount `xml:"kubernetes_service_account"`
-	LinodeLabel              *LinodeLabel      `xml:"linode_label,omitempty"`
+	KubernetesServiceAccount *K8ServiceAccount  `xml:"kubernetes_service_account"`
+	LinodeLabel              *LinodeLabel       `xml:"linode_label,omitempty"`
+	InstanceID               *WorkloadSelector  `xml:"instance_id,omitempty"`
+	Tags                     []WorkloadSelector `xml:"tag,omitempty"`
+	AzureSubscriptionID      *WorkloadSelector  `xml:"azure_subscription_id,omitempty"`
+	AzureResourceGroup       *WorkloadSelector  `xml:"azure_resource_group,omitempty"`
+	AzureVMName              *WorkloadSelector  `xml:"azure_vm_name,omitempty"`
+	GCPProject               *WorkloadSelector  `xml:"gcp_project,omitempty"`
+	GCPZone                  *WorkloadSelector  `xml:"gcp_zone,omitempty"`
+	GCPInstanceName          *WorkloadSelector  `xml:"gcp_instance_name,omitempty"`
 }
 
 type K8ServiceAccount struct {
@@ -124,6 +132,12 @@ type LinodeLabel struct {
 	Source string `xml:"source,attr,omitempty" `
 }
 
+// WorkloadSelector is a single workload matching criterion, such as an instance ID or tag.
+type WorkloadSelector struct {
+	Text   string `xml:",chardata"`
+	Source string `xml:"source,attr,omitempty"`
+}
+
 type Collection struct {
 	XMLName         xml.Name `xml:"collection"`
 	Text            string   `xml:",chardata"`
@@ -162,6 +176,18 @@ type KMIGroup struct {
 	Modifiers string   `xml:"modifiers"`
 }
 
+type KMIGroupMemberships struct {
+	XMLName     xml.Name             `xml:"group_memberships"`
+	Memberships []KMIGroupMembership `xml:"group_membership"`
+}
+
+type KMIGroupMembership struct {
+	Text   string `xml:",chardata"`
+	Parent string `xml:"parent,attr"`
+	Child  string `xml:"child,attr"`
+	Source string `xml:"source,attr"`
+}
+
 type KMIDefinition struct {
 	XMLName       xml.Name     `xml:"definition"`
 	Text          string       `xml:",chardata"`
@@ -176,27 +202,54 @@ type KMIDefinition struct {
 }
 
 type KMIDefinitionResponse struct {
-	XMLName       xml.Name `xml:"definition"`
-	Text          string   `xml:",chardata"`
-	Adders        string   `xml:"adders"`
-	Modifiers     string   `xml:"modifiers"`
-	Readers       string   `xml:"readers"`
-	Name          string   `xml:"name,attr"`
-	Source        string   `xml:"source,attr"`
-	Type          string   `xml:"type,attr"`
-	Modified      string   `xml:"modified,attr"`
-	AutoGenerate  string   `xml:"auto_generate"`
-	ExpirePeriod  string   `xml:"expire_period"`
-	RefreshPeriod string   `xml:"refresh_period"`
-	Option        []struct {
-		Text   string `xml:",chardata"`
-		Name   string `xml:"name,attr"`
-		Source string `xml:"source,attr"`
-	} `xml:"option"`
-	Secret []struct {
-		Text  string `xml:",chardata"`
-		Index string `xml:"index,attr"`
-	} `xml:"secret"`
+	XMLName       xml.Name              `xml:"definition"`
+	Text          string                `xml:",chardata"`
+	Adders        string                `xml:"adders"`
+	Modifiers     string                `xml:"modifiers"`
+	Readers       string                `xml:"readers"`
+	Name          string                `xml:"name,attr"`
+	Source        string                `xml:"source,attr"`
+	Type          string                `xml:"type,attr"`
+	Modified      string                `xml:"modified,attr"`
+	AutoGenerate  string                `xml:"auto_generate"`
+	ExpirePeriod  string                `xml:"expire_period"`
+	RefreshPeriod string                `xml:"refresh_period"`
+	Option        []KMIDefinitionOption `xml:"option"`
+	Secret        []KMIDefinitionSecret `xml:"secret"`
+}
+
+type KMIDefinitionOption struct {
+	Text   string `xml:",chardata"`
+	Name   string `xml:"name,attr"`
+	Source string `xml:"source,attr"`
+}
+
+// KMIDefinitionSecret is a secret generated or stored under a definition.
+type KMIDefinitionSecret struct {
+	Text       string `xml:",chardata"`
+	Index      string `xml:"index,attr"`
+	AddDate    string `xml:"add_date,attr"`
+	ExpireDate string `xml:"expire_date,attr"`
+	Status     string `xml:"status,attr"`
+}
+
+// KMISecretResponse is a secret read back from KMI with all of its blocks.
+type KMISecretResponse struct {
+	XMLName    xml.Name         `xml:"secret"`
+	Text       string           `xml:",chardata"`
+	Index      string           `xml:"index,attr"`
+	AddDate    string           `xml:"add_date,attr"`
+	ExpireDate string           `xml:"expire_date,attr"`
+	Status     string           `xml:"status,attr"`
+	Block      []KMISecretBlock `xml:"block"`
+}
+
+// KMISecretBlock is a named block of a secret, such as the certificate or key
+// of an ssl_cert secret.
+type KMISecretBlock struct {
+	Text       string `xml:",chardata"`
+	Name       string `xml:"name,attr"`
+	B64Encoded string `xml:"b64encoded,attr"`
 }
 
 type BlockSecret struct {
@@ -215,15 +268,23 @@ type Template struct {
 	Constraints   []ConstraintType         `xml:"constraint"`
 	Collectionacl *ApproveClientCollection `xml:"collectionacl"`
 }
+
+// SignRequest submits a certificate signing request against a template.
+type SignRequest struct {
+	XMLName xml.Name `xml:"sign"`
+	CSR     string   `xml:"csr"`
+	TTL     string   `xml:"ttl,omitempty"`
+}
+
 type TemplateResponse struct {
-	XMLName       xml.Name                 `xml:"template"`
-	Text          string                   `xml:",chardata"`
-	Constraints   []ConstraintTypeResponse `xml:"constraint"`
-	Collectionacl ApproveClientCollection  `xml:"collectionacl"`
-	Name          string                   `xml:"name,attr"`
-	Source        string                   `xml:"source,attr"`
-	AddDate       string                   `xml:"add_date,attr"`
-	Modified      string                   `xml:"modified,attr"`
+	XMLName       xml.Name                  `xml:"template"`
+	Text          string                    `xml:",chardata"`
+	Constraints   []ConstraintTypeResponse  `xml:"constraint"`
+	Collectionacl []ApproveClientCollection `xml:"collectionacl"`
+	Name          string                    `xml:"name,attr"`
+	Source        string                    `xml:"source,attr"`
+	AddDate       string                    `xml:"add_date,attr"`
+	Modified      string                    `xml:"modified,attr"`
 }
 
 type ConstraintTypeResponse struct {
@@ -271,3 +271,44 @@ func Test_BlockSecretMarshalling(t *testing.T) {
 		t.Errorf("Marshalling() = %v, want %v", string(out), string(data))
 	}
 }
+
+func Test_WorkloadSelectorsMarshalling(t *testing.T) {
+	wk := Workload{
+		Projection: "vm",
+		InstanceID: &WorkloadSelector{Text: "12345"},
+		Tags:       []WorkloadSelector{{Text: "web"}, {Text: "prod"}},
+		GCPProject: &WorkloadSelector{Text: "my-project"},
+		GCPZone:    &WorkloadSelector{Text: "us-east1-b"},
+	}
+	wk.Region.Text = "us-east1"
+
+	out, _ := xml.MarshalIndent(wk, "", "")
+	expected := []byte(`<workload projection="vm"><region>us-east1</region><instance_id>12345</instance_id><tag>web</tag><tag>prod</tag><gcp_project>my-project</gcp_project><gcp_zone>us-east1-b</gcp_zone></workload>`)
+	if !reflect.DeepEqual(out, expected) {
+		t.Errorf("Marshalling() = %v, want %v", string(out), string(expected))
+	}
+}
+
+func Test_WorkloadSelectorsUnmarshalling(t *testing.T) {
+	data := []byte(`<workload projection="vm" source="restserv:user:hachandr_kmi_cert">
+	<region source="restserv:user:hachandr_kmi_cert">eastus</region>
+	<tag source="restserv:user:hachandr_kmi_cert">web</tag>
+	<azure_subscription_id source="restserv:user:hachandr_kmi_cert">sub-1</azure_subscription_id>
+	<azure_vm_name source="restserv:user:hachandr_kmi_cert">vm-*</azure_vm_name>
+  </workload>`)
+	var e1 Workload
+	err := xml.Unmarshal(data, &e1)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(e1.Tags[0].Text, "web") {
+		t.Errorf("Marshalling() = %v, want %v", e1.Tags[0].Text, "web")
+	}
+	if !reflect.DeepEqual(e1.AzureSubscriptionID.Text, "sub-1") {
+		t.Errorf("Marshalling() = %v, want %v", e1.AzureSubscriptionID.Text, "sub-1")
+	}
+	if e1.AzureResourceGroup != nil || e1.LinodeLabel != nil {
+		t.Errorf("Marshalling() unexpected selectors %v %v", e1.AzureResourceGroup, e1.LinodeLabel)
+	}
+}
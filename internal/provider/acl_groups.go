@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// kmigroupchecker is the part of the KMI client needed to check ACL groups.
+type kmigroupchecker interface {
+	GroupExists(groupName string) (bool, error)
+}
+
+// aclGroupReference is an attribute naming a KMI group.
+type aclGroupReference struct {
+	path  path.Path
+	group types.String
+}
+
+// aclGroupReferences returns a reference for the adders, modifiers and readers
+// attributes of a collection or definition.
+func aclGroupReferences(adders, modifiers, readers types.String) []aclGroupReference {
+	return []aclGroupReference{
+		{path.Root("adders"), adders},
+		{path.Root("modifiers"), modifiers},
+		{path.Root("readers"), readers},
+	}
+}
+
+// signACLGroupReferences returns a reference for every group of signaclgroup,
+// below the given parent path.
+func signACLGroupReferences(parent path.Path, cert *SSLCert) []aclGroupReference {
+	if cert == nil {
+		return nil
+	}
+	var references []aclGroupReference
+	for _, group := range setStrings(cert.SignACLGroup) {
+		references = append(references, aclGroupReference{
+			path:  parent.AtName("signaclgroup").AtSetValue(types.StringValue(group)),
+			group: types.StringValue(group),
+		})
+	}
+	return references
+}
+
+// checkACLGroups adds an error for every referenced group KMI does not know.
+// Unknown and unset references are skipped, so groups computed during apply
+// are not checked.
+func checkACLGroups(client kmigroupchecker, references []aclGroupReference, diags *diag.Diagnostics) {
+	exists := map[string]bool{}
+	for _, reference := range references {
+		if reference.group.IsNull() || reference.group.IsUnknown() || reference.group.ValueString() == "" {
+			continue
+		}
+		group := reference.group.ValueString()
+		found, checked := exists[group]
+		if !checked {
+			var err error
+			found, err = client.GroupExists(group)
+			if err != nil {
+				diags.AddAttributeWarning(
+					reference.path,
+					"Could not verify KMI Group",
+					fmt.Sprintf("Could not check that group %s exists, unexpected error: %s", group, err.Error()),
+				)
+				found = true
+			}
+			exists[group] = found
+		}
+		if !found {
+			diags.AddAttributeError(
+				reference.path,
+				"Missing KMI Group",
+				fmt.Sprintf("Group %s does not exist in KMI. Create the group before referencing it in an ACL.", group),
+			)
+		}
+	}
+}
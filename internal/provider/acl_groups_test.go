@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type fakeGroupChecker struct {
+	groups map[string]bool
+	calls  map[string]int
+}
+
+func (c *fakeGroupChecker) GroupExists(groupName string) (bool, error) {
+	c.calls[groupName]++
+	if groupName == "PIM_BROKEN" {
+		return false, errors.New("connection reset")
+	}
+	return c.groups[groupName], nil
+}
+
+func TestCheckACLGroups(t *testing.T) {
+	t.Parallel()
+
+	client := &fakeGroupChecker{
+		groups: map[string]bool{"PIM_ADMIN": true, "PIM_READERS": true},
+		calls:  map[string]int{},
+	}
+	references := aclGroupReferences(types.StringValue("PIM_ADMIN"), types.StringValue("PIM_ADMIN"), types.StringValue("PIM_MISSING"))
+	references = append(references, aclGroupReference{path.Root("unknown"), types.StringUnknown()})
+	references = append(references, aclGroupReference{path.Root("null"), types.StringNull()})
+	references = append(references, signACLGroupReferences(path.Root("ssl_cert"), &SSLCert{
+		SignACLGroup: types.SetValueMust(types.StringType, []attr.Value{
+			types.StringValue("PIM_READERS"),
+			types.StringValue("PIM_SIGNERS"),
+		}),
+	})...)
+
+	var diags diag.Diagnostics
+	checkACLGroups(client, references, &diags)
+
+	if got := diags.ErrorsCount(); got != 2 {
+		t.Fatalf("checkACLGroups() errors = %v, want 2", diags)
+	}
+	errs := diags.Errors()
+	if !strings.Contains(errs[0].Detail(), "PIM_MISSING") || !strings.Contains(errs[1].Detail(), "PIM_SIGNERS") {
+		t.Errorf("checkACLGroups() errors = %v, want PIM_MISSING and PIM_SIGNERS", errs)
+	}
+	if withPath, ok := errs[1].(diag.DiagnosticWithPath); !ok || !withPath.Path().Equal(path.Root("ssl_cert").AtName("signaclgroup").AtSetValue(types.StringValue("PIM_SIGNERS"))) {
+		t.Errorf("checkACLGroups() error path = %v", errs[1])
+	}
+	if client.calls["PIM_ADMIN"] != 1 {
+		t.Errorf("GroupExists(PIM_ADMIN) called %d times, want 1", client.calls["PIM_ADMIN"])
+	}
+	if len(client.calls) != 4 {
+		t.Errorf("GroupExists() called for %v, want only known groups", client.calls)
+	}
+}
+
+func TestCheckACLGroupsUnverified(t *testing.T) {
+	t.Parallel()
+
+	client := &fakeGroupChecker{calls: map[string]int{}}
+	var diags diag.Diagnostics
+	checkACLGroups(client, aclGroupReferences(types.StringValue("PIM_BROKEN"), types.StringNull(), types.StringValue("")), &diags)
+
+	if diags.HasError() || diags.WarningsCount() != 1 {
+		t.Errorf("checkACLGroups() diagnostics = %v, want a single warning", diags)
+	}
+}
@@ -0,0 +1,338 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"terraform-provider-kmi/internal/kmi"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// certificateAttribute returns the computed details of the active certificate of
+// an ssl_cert definition.
+func certificateAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Computed:    true,
+		Description: "Details of the certificate held by the active secret of the definition. Null until KMI has generated a certificate. ",
+		Attributes: map[string]schema.Attribute{
+			"certificate_pem": schema.StringAttribute{
+				Computed:    true,
+				Description: "PEM encoded certificate chain, leaf certificate first. ",
+			},
+			"serial_number": schema.StringAttribute{
+				Computed:    true,
+				Description: "Serial number of the certificate in decimal. ",
+			},
+			"not_before": schema.StringAttribute{
+				Computed:    true,
+				Description: "Start of the validity period of the certificate in RFC3339 format. ",
+			},
+			"not_after": schema.StringAttribute{
+				Computed:    true,
+				Description: "End of the validity period of the certificate in RFC3339 format. ",
+			},
+			"subject": schema.StringAttribute{
+				Computed:    true,
+				Description: "Distinguished name of the certificate subject. ",
+			},
+			"issuer": schema.StringAttribute{
+				Computed:    true,
+				Description: "Distinguished name of the certificate issuer. ",
+			},
+			"dns_names": schema.SetAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "DNS names of the Subject Alternative Name extension. ",
+			},
+			"uris": schema.SetAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "URIs of the Subject Alternative Name extension. ",
+			},
+			"ip_addresses": schema.SetAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "IP addresses of the Subject Alternative Name extension. ",
+			},
+			"sha256_fingerprint": schema.StringAttribute{
+				Computed:    true,
+				Description: "Hex encoded SHA-256 fingerprint of the DER encoded certificate. ",
+			},
+		},
+	}
+}
+
+// CertificateDetails maps the certificate parsed from an ssl_cert secret.
+type CertificateDetails struct {
+	CertificatePEM    types.String `tfsdk:"certificate_pem"`
+	SerialNumber      types.String `tfsdk:"serial_number"`
+	NotBefore         types.String `tfsdk:"not_before"`
+	NotAfter          types.String `tfsdk:"not_after"`
+	Subject           types.String `tfsdk:"subject"`
+	Issuer            types.String `tfsdk:"issuer"`
+	DNSNames          types.Set    `tfsdk:"dns_names"`
+	URIs              types.Set    `tfsdk:"uris"`
+	IPAddresses       types.Set    `tfsdk:"ip_addresses"`
+	SHA256Fingerprint types.String `tfsdk:"sha256_fingerprint"`
+}
+
+func (o CertificateDetails) attrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"certificate_pem":    types.StringType,
+		"serial_number":      types.StringType,
+		"not_before":         types.StringType,
+		"not_after":          types.StringType,
+		"subject":            types.StringType,
+		"issuer":             types.StringType,
+		"dns_names":          types.SetType{ElemType: types.StringType},
+		"uris":               types.SetType{ElemType: types.StringType},
+		"ip_addresses":       types.SetType{ElemType: types.StringType},
+		"sha256_fingerprint": types.StringType,
+	}
+}
+
+// kmisecretreader is implemented by the definition types which expose details of
+// the secret generated by KMI.
+type kmisecretreader interface {
+	ReadSecret(ctx context.Context, client *kmi.KMIRestClient, collectionName string, definitionName string, definitionDetails *kmi.KMIDefinitionResponse, diags *diag.Diagnostics)
+}
+
+// ReadSecret sets the certificate details from the active secret of the
+// definition. Failing to read the secret is reported as a warning, as the
+// definition can be managed by groups which are not readers.
+func (s *SSLCert) ReadSecret(ctx context.Context, client *kmi.KMIRestClient, collectionName string, definitionName string, definitionDetails *kmi.KMIDefinitionResponse, diags *diag.Diagnostics) {
+	s.Certificate = types.ObjectNull(CertificateDetails{}.attrTypes())
+
+	index := activeSecretIndex(definitionDetails)
+	if index == "" {
+		return
+	}
+	secret, err := client.GetSecret(collectionName, definitionName, index)
+	if err != nil {
+		diags.AddWarning(
+			"Error Reading certificate",
+			"Could not read secret "+index+" of definition "+definitionName+": "+err.Error(),
+		)
+		return
+	}
+	details, err := parseCertificateDetails(secret)
+	if err != nil {
+		diags.AddWarning(
+			"Error Reading certificate",
+			"Could not parse certificate of definition "+definitionName+": "+err.Error(),
+		)
+		return
+	}
+	certificate, d := types.ObjectValueFrom(ctx, CertificateDetails{}.attrTypes(), details)
+	diags.Append(d...)
+	s.Certificate = certificate
+}
+
+// activeSecretIndex returns the index of the active secret of the definition.
+// KMI may hold pre-generated secrets which are not active yet, so the highest
+// index is only used when KMI reports no secret status at all. It returns an
+// empty string when there is no active secret.
+func activeSecretIndex(definitionDetails *kmi.KMIDefinitionResponse) string {
+	latest, active := "", ""
+	latestIndex, activeIndex := int64(-1), int64(-1)
+	statusReported := false
+	for _, secret := range definitionDetails.Secret {
+		if secret.Status != "" {
+			statusReported = true
+		}
+		index, err := strconv.ParseInt(secret.Index, 10, 64)
+		if err != nil {
+			continue
+		}
+		if index > latestIndex {
+			latest, latestIndex = secret.Index, index
+		}
+		if strings.EqualFold(secret.Status, "active") && index > activeIndex {
+			active, activeIndex = secret.Index, index
+		}
+	}
+	if !statusReported {
+		return latest
+	}
+	return active
+}
+
+// parseCertificateDetails parses the certificates held by the blocks of secret.
+// The first certificate is the leaf, the others form the chain. Blocks which
+// are not certificates, such as the private key, are skipped.
+func parseCertificateDetails(secret *kmi.KMISecretResponse) (CertificateDetails, error) {
+	var certificates []*x509.Certificate
+	for _, block := range secret.Block {
+		data := []byte(strings.TrimSpace(block.Text))
+		if strings.EqualFold(block.B64Encoded, "true") {
+			decoded, err := base64.StdEncoding.DecodeString(string(data))
+			if err != nil {
+				return CertificateDetails{}, errors.New("block " + block.Name + " is not valid base64: " + err.Error())
+			}
+			data = decoded
+		}
+		parsed, err := parseCertificates(data)
+		if err != nil {
+			return CertificateDetails{}, errors.New("block " + block.Name + ": " + err.Error())
+		}
+		certificates = append(certificates, parsed...)
+	}
+	if len(certificates) == 0 {
+		return CertificateDetails{}, errors.New("secret " + secret.Index + " holds no certificate")
+	}
+
+	var chain bytes.Buffer
+	for _, certificate := range certificates {
+		_ = pem.Encode(&chain, &pem.Block{Type: "CERTIFICATE", Bytes: certificate.Raw})
+	}
+
+	leaf := certificates[0]
+	uris := []string{}
+	for _, uri := range leaf.URIs {
+		uris = append(uris, uri.String())
+	}
+	ips := []string{}
+	for _, ip := range leaf.IPAddresses {
+		ips = append(ips, ip.String())
+	}
+	fingerprint := sha256.Sum256(leaf.Raw)
+	return CertificateDetails{
+		CertificatePEM:    types.StringValue(chain.String()),
+		SerialNumber:      types.StringValue(leaf.SerialNumber.String()),
+		NotBefore:         types.StringValue(leaf.NotBefore.UTC().Format(time.RFC3339)),
+		NotAfter:          types.StringValue(leaf.NotAfter.UTC().Format(time.RFC3339)),
+		Subject:           types.StringValue(leaf.Subject.String()),
+		Issuer:            types.StringValue(leaf.Issuer.String()),
+		DNSNames:          stringsToSet(leaf.DNSNames),
+		URIs:              stringsToSet(uris),
+		IPAddresses:       stringsToSet(ips),
+		SHA256Fingerprint: types.StringValue(hex.EncodeToString(fingerprint[:])),
+	}, nil
+}
+
+// parseCertificates parses the PEM CERTIFICATE blocks of data, or data itself
+// when it is DER encoded. Data holding neither returns no certificates.
+func parseCertificates(data []byte) ([]*x509.Certificate, error) {
+	if !bytes.Contains(data, []byte("-----BEGIN")) {
+		certificates, err := x509.ParseCertificates(data)
+		if err != nil {
+			return nil, nil
+		}
+		return certificates, nil
+	}
+
+	var certificates []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return certificates, nil
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certificates = append(certificates, certificate)
+	}
+}
+
+// sslCertHolder is implemented by the models which hold an ssl_cert definition.
+type sslCertHolder interface {
+	sslCert() *SSLCert
+}
+
+func (s *SSLCert) sslCert() *SSLCert {
+	return s
+}
+
+// checkCertificateExpiry warns when the certificate of prior, the refreshed state,
+// expires within the expiry_warning_window of plan. An expired certificate is an
+// error when error_if_expired is set.
+func checkCertificateExpiry(ctx context.Context, definitionName string, prior *SSLCert, plan *SSLCert, now time.Time, diags *diag.Diagnostics) {
+	if prior == nil || plan == nil || prior.Certificate.IsNull() || prior.Certificate.IsUnknown() {
+		return
+	}
+	var details CertificateDetails
+	diags.Append(prior.Certificate.As(ctx, &details, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return
+	}
+	notAfter, err := time.Parse(time.RFC3339, details.NotAfter.ValueString())
+	if err != nil {
+		return
+	}
+
+	if !notAfter.After(now) {
+		if plan.ErrorIfExpired.ValueBool() {
+			diags.AddError(
+				"Certificate expired",
+				fmt.Sprintf("The certificate %s of definition %s expired at %s. Check the refresh_period of the definition.", details.SerialNumber.ValueString(), definitionName, details.NotAfter.ValueString()),
+			)
+			return
+		}
+	}
+
+	if plan.ExpiryWarning.IsNull() || plan.ExpiryWarning.IsUnknown() {
+		return
+	}
+	deadline, err := addKMIPeriod(now, plan.ExpiryWarning.ValueString())
+	if err != nil {
+		diags.AddError(
+			"Invalid expiry_warning_window",
+			"Could not parse expiry_warning_window: "+err.Error(),
+		)
+		return
+	}
+	if notAfter.Before(deadline) {
+		diags.AddWarning(
+			"Certificate expiring",
+			fmt.Sprintf("The certificate %s of definition %s expires at %s, within the expiry_warning_window of %s. Check the refresh_period of the definition.", details.SerialNumber.ValueString(), definitionName, details.NotAfter.ValueString(), plan.ExpiryWarning.ValueString()),
+		)
+	}
+}
+
+// addKMIPeriod adds a KMI period such as "30 days" or "3 months" to t.
+func addKMIPeriod(t time.Time, period string) (time.Time, error) {
+	period = strings.TrimSpace(period)
+	if !kmiPeriodRegex.MatchString(period) {
+		return t, fmt.Errorf("%q is not a KMI period such as \"30 days\"", period)
+	}
+	unitStart := strings.IndexFunc(period, func(r rune) bool { return r < '0' || r > '9' })
+	count, err := strconv.Atoi(period[:unitStart])
+	if err != nil {
+		return t, err
+	}
+	switch strings.TrimSuffix(strings.TrimSpace(period[unitStart:]), "s") {
+	case "second":
+		return t.Add(time.Duration(count) * time.Second), nil
+	case "minute":
+		return t.Add(time.Duration(count) * time.Minute), nil
+	case "hour":
+		return t.Add(time.Duration(count) * time.Hour), nil
+	case "day":
+		return t.AddDate(0, 0, count), nil
+	case "week":
+		return t.AddDate(0, 0, 7*count), nil
+	case "month":
+		return t.AddDate(0, count, 0), nil
+	default:
+		return t.AddDate(count, 0, 0), nil
+	}
+}
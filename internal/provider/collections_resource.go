@@ -9,14 +9,18 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource              = &collectionsResource{}
-	_ resource.ResourceWithConfigure = &collectionsResource{}
+	_ resource.Resource               = &collectionsResource{}
+	_ resource.ResourceWithConfigure  = &collectionsResource{}
+	_ resource.ResourceWithModifyPlan = &collectionsResource{}
 )
 
 // NewOrderResource is a helper function to simplify the provider implementation.
@@ -53,21 +57,48 @@ func (r *collectionsResource) Schema(_ context.Context, _ resource.SchemaRequest
 			"name": schema.StringAttribute{
 				Required:    true,
 				Description: "The name of the collection to create. ",
+				Validators: []validator.String{
+					kmiNameValidator(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"account_name": schema.StringAttribute{
 				Required:    true,
 				Description: "The name of the account that KMI has been enabled for. ",
+				Validators: []validator.String{
+					kmiNameValidator(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"last_updated": schema.StringAttribute{
-				Computed: true,
+				Computed:    true,
+				Description: "The KMI modified timestamp of the collection. ",
 			},
 			"distributed_date": schema.StringAttribute{
-				Computed: true,
+				Computed:    true,
+				Description: "The last time the collection was distributed. ",
 			},
 		},
 	}
 }
 
+// ModifyPlan reports ACL groups of the collection which do not exist in KMI.
+func (r *collectionsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+	var plan collectionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	checkACLGroups(r.client, aclGroupReferences(plan.Adders, plan.Modifiers, plan.Readers), &resp.Diagnostics)
+}
+
 // Create creates the resource and sets the initial Terraform state.
 func (r *collectionsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan collectionResourceModel
@@ -76,14 +107,6 @@ func (r *collectionsResource) Create(ctx context.Context, req resource.CreateReq
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	_, err := r.client.GetGroup(plan.Readers.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Getting Reader Group on Collection Create",
-			"Could not get reader group, unexpected error: "+err.Error(),
-		)
-		return
-	}
 
 	collection := kmi.CollectionRequest{
 		Adders:    plan.Adders.ValueString(),
@@ -124,7 +147,7 @@ func (r *collectionsResource) Create(ctx context.Context, req resource.CreateReq
 	}
 
 	plan.DistributedDate = types.StringValue(response.DistributedDate)
-	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+	plan.LastUpdated = types.StringValue(response.Modified)
 
 	// Set state to fully populated data
 
@@ -156,11 +179,13 @@ func (r *collectionsResource) Read(ctx context.Context, req resource.ReadRequest
 	}
 
 	state = collectionResourceModel{
-		Adders:         types.StringValue(kmicollection.Adders),
-		Modifiers:      types.StringValue(kmicollection.Modifiers),
-		Readers:        types.StringValue(kmicollection.Readers),
-		CollectionName: types.StringValue(kmicollection.Name),
-		AccountName:    types.StringValue(kmicollection.Account),
+		Adders:          types.StringValue(kmicollection.Adders),
+		Modifiers:       types.StringValue(kmicollection.Modifiers),
+		Readers:         types.StringValue(kmicollection.Readers),
+		CollectionName:  types.StringValue(kmicollection.Name),
+		AccountName:     types.StringValue(kmicollection.Account),
+		LastUpdated:     types.StringValue(kmicollection.Modified),
+		DistributedDate: types.StringValue(kmicollection.DistributedDate),
 	}
 
 	// Set refreshed state
@@ -195,7 +220,7 @@ func (r *collectionsResource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 
-	_, err = r.client.GetCollection(plan.CollectionName.ValueString())
+	response, err := r.client.GetCollection(plan.CollectionName.ValueString())
 
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -205,7 +230,8 @@ func (r *collectionsResource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 
-	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+	plan.DistributedDate = types.StringValue(response.DistributedDate)
+	plan.LastUpdated = types.StringValue(response.Modified)
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, plan)
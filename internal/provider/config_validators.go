@@ -0,0 +1,199 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.ConfigValidator = exactlyOneOfValidator{}
+	_ resource.ConfigValidator = conflictingValidator{}
+	_ resource.ConfigValidator = stringsValidWhenValidator{}
+	_ resource.ConfigValidator = attributesForValueValidator{}
+)
+
+// exactlyOneOfValidator checks that exactly one of the given attributes is configured.
+type exactlyOneOfValidator struct {
+	paths []path.Path
+}
+
+func (v exactlyOneOfValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("Exactly one of these attributes must be configured: %s", joinPaths(v.paths))
+}
+
+func (v exactlyOneOfValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v exactlyOneOfValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	configured, unknown := configuredPaths(ctx, req, resp, v.paths)
+	if resp.Diagnostics.HasError() || unknown {
+		return
+	}
+
+	if len(configured) != 1 {
+		resp.Diagnostics.AddError(
+			"Invalid Attribute Combination",
+			fmt.Sprintf("Exactly one of these attributes must be configured: %s, got: %s", joinPaths(v.paths), joinPaths(configured)),
+		)
+	}
+}
+
+// conflictingValidator checks that at most one of the given attributes is configured.
+type conflictingValidator struct {
+	paths []path.Path
+}
+
+func (v conflictingValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("These attributes cannot be configured together: %s", joinPaths(v.paths))
+}
+
+func (v conflictingValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v conflictingValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	configured, _ := configuredPaths(ctx, req, resp, v.paths)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if len(configured) > 1 {
+		resp.Diagnostics.AddAttributeError(
+			configured[0],
+			"Invalid Attribute Combination",
+			fmt.Sprintf("These attributes cannot be configured together: %s", joinPaths(configured)),
+		)
+	}
+}
+
+// stringsValidWhenValidator applies a string validator to the given attributes
+// while the when attribute is configured.
+type stringsValidWhenValidator struct {
+	when      path.Path
+	paths     []path.Path
+	validator validator.String
+}
+
+func (v stringsValidWhenValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("When %s is configured, %s must each be %s", v.when, joinPaths(v.paths), v.validator.Description(ctx))
+}
+
+func (v stringsValidWhenValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v stringsValidWhenValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	configured, unknown := configuredPaths(ctx, req, resp, []path.Path{v.when})
+	if resp.Diagnostics.HasError() || unknown || len(configured) == 0 {
+		return
+	}
+
+	for _, p := range v.paths {
+		var value types.String
+		diags := req.Config.GetAttribute(ctx, p, &value)
+		resp.Diagnostics.Append(diags...)
+		if diags.HasError() {
+			return
+		}
+		validateResp := &validator.StringResponse{}
+		v.validator.ValidateString(ctx, validator.StringRequest{
+			Path:           p,
+			PathExpression: p.Expression(),
+			Config:         req.Config,
+			ConfigValue:    value,
+		}, validateResp)
+		resp.Diagnostics.Append(validateResp.Diagnostics...)
+	}
+}
+
+// attributesForValueValidator checks that the required attributes are configured
+// and the forbidden attributes are not while the string attribute at path holds value.
+type attributesForValueValidator struct {
+	path      path.Path
+	value     string
+	required  []path.Path
+	forbidden []path.Path
+}
+
+func (v attributesForValueValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("When %s is %q, %s must be configured and %s must not be configured", v.path, v.value, joinPaths(v.required), joinPaths(v.forbidden))
+}
+
+func (v attributesForValueValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v attributesForValueValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var value types.String
+	diags := req.Config.GetAttribute(ctx, v.path, &value)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() || value.IsNull() || value.IsUnknown() || value.ValueString() != v.value {
+		return
+	}
+
+	for _, p := range v.required {
+		configured, unknown := configuredPaths(ctx, req, resp, []path.Path{p})
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if len(configured) == 0 && !unknown {
+			resp.Diagnostics.AddAttributeError(
+				p,
+				"Missing Attribute Configuration",
+				fmt.Sprintf("%s must be configured when %s is %q", p, v.path, v.value),
+			)
+		}
+	}
+	for _, p := range v.forbidden {
+		configured, _ := configuredPaths(ctx, req, resp, []path.Path{p})
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if len(configured) > 0 {
+			resp.Diagnostics.AddAttributeError(
+				p,
+				"Invalid Attribute Combination",
+				fmt.Sprintf("%s cannot be configured when %s is %q", p, v.path, v.value),
+			)
+		}
+	}
+}
+
+// configuredPaths returns the paths which hold a known, non-null value and whether
+// any of the paths is still unknown.
+func configuredPaths(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse, paths []path.Path) ([]path.Path, bool) {
+	var configured []path.Path
+	unknown := false
+	for _, p := range paths {
+		var value attr.Value
+		diags := req.Config.GetAttribute(ctx, p, &value)
+		resp.Diagnostics.Append(diags...)
+		if diags.HasError() {
+			return nil, false
+		}
+		if value.IsUnknown() {
+			unknown = true
+			continue
+		}
+		if !value.IsNull() {
+			configured = append(configured, p)
+		}
+	}
+	return configured, unknown
+}
+
+func joinPaths(paths []path.Path) string {
+	names := make([]string, 0, len(paths))
+	for _, p := range paths {
+		names = append(names, p.String())
+	}
+	return "[" + strings.Join(names, ", ") + "]"
+}
@@ -0,0 +1,250 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// testResourceConfig builds a configuration for the resource schema where every
+// attribute is null except the given values.
+func testResourceConfig(t *testing.T, r fwresource.Resource, values map[string]tftypes.Value) tfsdk.Config {
+	ctx := context.Background()
+	schemaResponse := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResponse)
+
+	objectType, ok := schemaResponse.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	if !ok {
+		t.Fatalf("unexpected schema type %T", schemaResponse.Schema.Type().TerraformType(ctx))
+	}
+	attributes := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		attributes[name] = tftypes.NewValue(attributeType, nil)
+	}
+	for name, value := range values {
+		attributes[name] = value
+	}
+
+	return tfsdk.Config{
+		Schema: schemaResponse.Schema,
+		Raw:    tftypes.NewValue(objectType, attributes),
+	}
+}
+
+// testSSLCertValue builds an ssl_cert object with the given attributes set.
+func testSSLCertValue(t *testing.T, values map[string]tftypes.Value) tftypes.Value {
+	return testDefinitionBlockValue(t, "ssl_cert", values)
+}
+
+// testDefinitionBlockValue builds an object for the definition type block with
+// the given attributes set.
+func testDefinitionBlockValue(t *testing.T, block string, values map[string]tftypes.Value) tftypes.Value {
+	ctx := context.Background()
+	schemaResponse := &fwresource.SchemaResponse{}
+	NewDefinitionsResource().Schema(ctx, fwresource.SchemaRequest{}, schemaResponse)
+
+	blockType, diags := schemaResponse.Schema.TypeAtPath(ctx, path.Root(block))
+	if diags.HasError() {
+		t.Fatalf("%s type diagnostics: %+v", block, diags)
+	}
+	objectType, ok := blockType.TerraformType(ctx).(tftypes.Object)
+	if !ok {
+		t.Fatalf("unexpected %s type", block)
+	}
+	attributes := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		attributes[name] = tftypes.NewValue(attributeType, nil)
+	}
+	for name, value := range values {
+		attributes[name] = value
+	}
+	return tftypes.NewValue(objectType, attributes)
+}
+
+func TestDefinitionsResourceConfigValidators(t *testing.T) {
+	t.Parallel()
+
+	autoGenerate := map[string]tftypes.Value{"auto_generate": tftypes.NewValue(tftypes.Bool, true)}
+
+	tests := []struct {
+		name      string
+		values    map[string]tftypes.Value
+		wantError bool
+	}{
+		{
+			name:      "no definition type",
+			values:    map[string]tftypes.Value{},
+			wantError: true,
+		},
+		{
+			name: "opaque only",
+			values: map[string]tftypes.Value{
+				"opaque": tftypes.NewValue(tftypes.String, "secret"),
+			},
+		},
+		{
+			name: "opaque and transparent",
+			values: map[string]tftypes.Value{
+				"opaque":      tftypes.NewValue(tftypes.String, "secret"),
+				"transparent": tftypes.NewValue(tftypes.String, "secret"),
+			},
+			wantError: true,
+		},
+		{
+			name: "unknown opaque is skipped",
+			values: map[string]tftypes.Value{
+				"opaque": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			},
+		},
+		{
+			name: "ssl_cert only",
+			values: map[string]tftypes.Value{
+				"ssl_cert": testSSLCertValue(t, autoGenerate),
+			},
+		},
+		{
+			name: "ssl_cert issuer and is_ca",
+			values: map[string]tftypes.Value{
+				"ssl_cert": testSSLCertValue(t, map[string]tftypes.Value{
+					"auto_generate": tftypes.NewValue(tftypes.Bool, true),
+					"issuer":        tftypes.NewValue(tftypes.String, "ca"),
+					"is_ca":         tftypes.NewValue(tftypes.Number, 1),
+				}),
+			},
+			wantError: true,
+		},
+		{
+			name: "ssl_cert subject and cn",
+			values: map[string]tftypes.Value{
+				"ssl_cert": testSSLCertValue(t, map[string]tftypes.Value{
+					"auto_generate": tftypes.NewValue(tftypes.Bool, true),
+					"subject":       tftypes.NewValue(tftypes.String, "/CN=test"),
+					"cn":            tftypes.NewValue(tftypes.String, "test"),
+				}),
+			},
+			wantError: true,
+		},
+		{
+			name: "asymmetric_key ec with curve",
+			values: map[string]tftypes.Value{
+				"asymmetric_key": testDefinitionBlockValue(t, "asymmetric_key", map[string]tftypes.Value{
+					"auto_generate": tftypes.NewValue(tftypes.Bool, true),
+					"key_type":      tftypes.NewValue(tftypes.String, "ec"),
+					"curve":         tftypes.NewValue(tftypes.String, "secp384r1"),
+				}),
+			},
+		},
+		{
+			name: "asymmetric_key ec with key_size and no curve",
+			values: map[string]tftypes.Value{
+				"asymmetric_key": testDefinitionBlockValue(t, "asymmetric_key", map[string]tftypes.Value{
+					"auto_generate": tftypes.NewValue(tftypes.Bool, true),
+					"key_type":      tftypes.NewValue(tftypes.String, "ec"),
+					"key_size":      tftypes.NewValue(tftypes.Number, 2048),
+				}),
+			},
+			wantError: true,
+		},
+		{
+			name: "asymmetric_key rsa with key_size",
+			values: map[string]tftypes.Value{
+				"asymmetric_key": testDefinitionBlockValue(t, "asymmetric_key", map[string]tftypes.Value{
+					"auto_generate": tftypes.NewValue(tftypes.Bool, true),
+					"key_type":      tftypes.NewValue(tftypes.String, "rsa"),
+					"key_size":      tftypes.NewValue(tftypes.Number, 4096),
+				}),
+			},
+		},
+		{
+			name: "asymmetric_key rsa without key_size",
+			values: map[string]tftypes.Value{
+				"asymmetric_key": testDefinitionBlockValue(t, "asymmetric_key", map[string]tftypes.Value{
+					"auto_generate": tftypes.NewValue(tftypes.Bool, true),
+					"key_type":      tftypes.NewValue(tftypes.String, "rsa"),
+				}),
+			},
+			wantError: true,
+		},
+		{
+			name: "asymmetric_key rsa with curve",
+			values: map[string]tftypes.Value{
+				"asymmetric_key": testDefinitionBlockValue(t, "asymmetric_key", map[string]tftypes.Value{
+					"auto_generate": tftypes.NewValue(tftypes.Bool, true),
+					"key_type":      tftypes.NewValue(tftypes.String, "rsa"),
+					"key_size":      tftypes.NewValue(tftypes.Number, 4096),
+					"curve":         tftypes.NewValue(tftypes.String, "secp384r1"),
+				}),
+			},
+			wantError: true,
+		},
+		{
+			name: "asymmetric_key unknown key_type is skipped",
+			values: map[string]tftypes.Value{
+				"asymmetric_key": testDefinitionBlockValue(t, "asymmetric_key", map[string]tftypes.Value{
+					"auto_generate": tftypes.NewValue(tftypes.Bool, true),
+					"key_type":      tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+				}),
+			},
+		},
+		{
+			name: "azure_sp with lower case names",
+			values: map[string]tftypes.Value{
+				"collection_name": tftypes.NewValue(tftypes.String, "pim_test"),
+				"name":            tftypes.NewValue(tftypes.String, "web-sp"),
+				"azure_sp":        testDefinitionBlockValue(t, "azure_sp", autoGenerate),
+			},
+		},
+		{
+			name: "azure_sp with upper case collection name",
+			values: map[string]tftypes.Value{
+				"collection_name": tftypes.NewValue(tftypes.String, "PIM_TEST"),
+				"name":            tftypes.NewValue(tftypes.String, "web-sp"),
+				"azure_sp":        testDefinitionBlockValue(t, "azure_sp", autoGenerate),
+			},
+			wantError: true,
+		},
+		{
+			name: "azure_sp with upper case name",
+			values: map[string]tftypes.Value{
+				"collection_name": tftypes.NewValue(tftypes.String, "pim_test"),
+				"name":            tftypes.NewValue(tftypes.String, "WebSP"),
+				"azure_sp":        testDefinitionBlockValue(t, "azure_sp", autoGenerate),
+			},
+			wantError: true,
+		},
+		{
+			name: "upper case names without azure_sp",
+			values: map[string]tftypes.Value{
+				"collection_name": tftypes.NewValue(tftypes.String, "PIM_TEST"),
+				"name":            tftypes.NewValue(tftypes.String, "WebSP"),
+				"opaque":          tftypes.NewValue(tftypes.String, "secret"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			r, ok := NewDefinitionsResource().(fwresource.ResourceWithConfigValidators)
+			if !ok {
+				t.Fatal("definitions resource does not implement ResourceWithConfigValidators")
+			}
+			req := fwresource.ValidateConfigRequest{Config: testResourceConfig(t, r, tt.values)}
+			resp := &fwresource.ValidateConfigResponse{}
+			for _, v := range r.ConfigValidators(ctx) {
+				v.ValidateResource(ctx, req, resp)
+			}
+
+			if resp.Diagnostics.HasError() != tt.wantError {
+				t.Errorf("ConfigValidators() diagnostics = %v, want error %v", resp.Diagnostics, tt.wantError)
+			}
+		})
+	}
+}
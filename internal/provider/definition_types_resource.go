@@ -0,0 +1,664 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"terraform-provider-kmi/internal/kmi"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                     = &definitionTypeResource{}
+	_ resource.ResourceWithConfigure        = &definitionTypeResource{}
+	_ resource.ResourceWithConfigValidators = &definitionTypeResource{}
+	_ resource.ResourceWithMoveState        = &definitionTypeResource{}
+	_ resource.ResourceWithImportState      = &definitionTypeResource{}
+	_ resource.ResourceWithModifyPlan       = &definitionTypeResource{}
+)
+
+// definitionType describes a KMI definition type managed by its own resource.
+// The type specific attributes are the same as the matching block of
+// kmi_definitions, flattened to the top level of the resource.
+type definitionType struct {
+	// name is the KMI definition type, also used in the resource type name.
+	name        string
+	description string
+	attributes  map[string]schema.Attribute
+	// nameValidators are additional validators for name and collection_name.
+	nameValidators   []validator.String
+	configValidators []resource.ConfigValidator
+	newModel         func() definitionTypeModel
+	// fromDefinitions converts kmi_definitions state into the model of this type,
+	// returning false when the state holds another definition type.
+	fromDefinitions func(definitionResourceModel) (definitionTypeModel, bool)
+}
+
+// definitionTypeModel is the model of a per-type definition resource.
+type definitionTypeModel interface {
+	kmigenerator
+	common() *definitionCommonModel
+}
+
+// definitionTypeBlockSecret is implemented by models whose secret is supplied
+// by the configuration rather than generated by KMI.
+type definitionTypeBlockSecret interface {
+	blockSecret() kmi.BlockSecret
+}
+
+func NewSSLCertDefinitionResource() resource.Resource {
+	return &definitionTypeResource{
+		definitionType: definitionType{
+			name:             "ssl_cert",
+			description:      "Manages a KMI SSL certificate definition.",
+			attributes:       sslCertAttributes(),
+			configValidators: sslCertConfigValidators(path.Empty()),
+			newModel:         func() definitionTypeModel { return &sslCertDefinitionModel{} },
+			fromDefinitions: func(m definitionResourceModel) (definitionTypeModel, bool) {
+				if m.SSLCert == nil {
+					return nil, false
+				}
+				return &sslCertDefinitionModel{definitionCommonModel: m.definitionCommonModel, SSLCert: *m.SSLCert}, true
+			},
+		},
+	}
+}
+
+func NewAzureSPDefinitionResource() resource.Resource {
+	return &definitionTypeResource{
+		definitionType: definitionType{
+			name:           "azure_sp",
+			description:    "Manages a KMI Azure Service Principal definition.",
+			attributes:     azureSPAttributes(),
+			nameValidators: []validator.String{kmiLowerNameValidator()},
+			newModel:       func() definitionTypeModel { return &azureSPDefinitionModel{} },
+			fromDefinitions: func(m definitionResourceModel) (definitionTypeModel, bool) {
+				if m.AzureSP == nil {
+					return nil, false
+				}
+				return &azureSPDefinitionModel{definitionCommonModel: m.definitionCommonModel, AzureSP: *m.AzureSP}, true
+			},
+		},
+	}
+}
+
+func NewSymmetricKeyDefinitionResource() resource.Resource {
+	return &definitionTypeResource{
+		definitionType: definitionType{
+			name:        "symmetric_key",
+			description: "Manages a KMI symmetric key definition.",
+			attributes:  symmetricKeyAttributes(),
+			newModel:    func() definitionTypeModel { return &symmetricKeyDefinitionModel{} },
+			fromDefinitions: func(m definitionResourceModel) (definitionTypeModel, bool) {
+				if m.SymetricKey == nil {
+					return nil, false
+				}
+				return &symmetricKeyDefinitionModel{definitionCommonModel: m.definitionCommonModel, SymetricKey: *m.SymetricKey}, true
+			},
+		},
+	}
+}
+
+func NewAsymmetricKeyDefinitionResource() resource.Resource {
+	return &definitionTypeResource{
+		definitionType: definitionType{
+			name:             "asymmetric_key",
+			description:      "Manages a KMI RSA or EC key pair definition.",
+			attributes:       asymmetricKeyAttributes(),
+			configValidators: asymmetricKeyConfigValidators(path.Empty()),
+			newModel:         func() definitionTypeModel { return &asymmetricKeyDefinitionModel{} },
+			fromDefinitions: func(m definitionResourceModel) (definitionTypeModel, bool) {
+				if m.AsymmetricKey == nil {
+					return nil, false
+				}
+				return &asymmetricKeyDefinitionModel{definitionCommonModel: m.definitionCommonModel, AsymmetricKey: *m.AsymmetricKey}, true
+			},
+		},
+	}
+}
+
+func NewSSHKeyDefinitionResource() resource.Resource {
+	return &definitionTypeResource{
+		definitionType: definitionType{
+			name:        "ssh_key",
+			description: "Manages a KMI SSH key pair definition.",
+			attributes:  sshKeyAttributes(),
+			newModel:    func() definitionTypeModel { return &sshKeyDefinitionModel{} },
+			fromDefinitions: func(m definitionResourceModel) (definitionTypeModel, bool) {
+				if m.SSHKey == nil {
+					return nil, false
+				}
+				return &sshKeyDefinitionModel{definitionCommonModel: m.definitionCommonModel, SSHKey: *m.SSHKey}, true
+			},
+		},
+	}
+}
+
+func NewPasswordDefinitionResource() resource.Resource {
+	return &definitionTypeResource{
+		definitionType: definitionType{
+			name:        "password",
+			description: "Manages a KMI auto-generated password definition.",
+			attributes:  passwordAttributes(),
+			newModel:    func() definitionTypeModel { return &passwordDefinitionModel{} },
+			fromDefinitions: func(m definitionResourceModel) (definitionTypeModel, bool) {
+				if m.Password == nil {
+					return nil, false
+				}
+				return &passwordDefinitionModel{definitionCommonModel: m.definitionCommonModel, Password: *m.Password}, true
+			},
+		},
+	}
+}
+
+func NewOpaqueDefinitionResource() resource.Resource {
+	return &definitionTypeResource{
+		definitionType: definitionType{
+			name:        "opaque",
+			description: "Manages a KMI opaque definition and its secret.",
+			attributes:  blockSecretAttributes("opaque"),
+			newModel:    func() definitionTypeModel { return &opaqueDefinitionModel{} },
+			fromDefinitions: func(m definitionResourceModel) (definitionTypeModel, bool) {
+				if m.Opaque.IsNull() {
+					return nil, false
+				}
+				return &opaqueDefinitionModel{
+					definitionCommonModel: m.definitionCommonModel,
+					Value:                 m.Opaque,
+					B64Encoded:            m.B64Encoded,
+				}, true
+			},
+		},
+	}
+}
+
+func NewTransparentDefinitionResource() resource.Resource {
+	return &definitionTypeResource{
+		definitionType: definitionType{
+			name:        "transparent",
+			description: "Manages a KMI transparent definition and its secret.",
+			attributes:  blockSecretAttributes("transparent"),
+			newModel:    func() definitionTypeModel { return &transparentDefinitionModel{} },
+			fromDefinitions: func(m definitionResourceModel) (definitionTypeModel, bool) {
+				if m.Transparent.IsNull() {
+					return nil, false
+				}
+				return &transparentDefinitionModel{
+					definitionCommonModel: m.definitionCommonModel,
+					Value:                 m.Transparent,
+					B64Encoded:            m.B64Encoded,
+				}, true
+			},
+		},
+	}
+}
+
+// blockSecretAttributes returns the attributes of the opaque and transparent
+// definition types, whose secret is supplied by the configuration.
+func blockSecretAttributes(definitionType string) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"value": schema.StringAttribute{
+			Required:    true,
+			Sensitive:   true,
+			Description: fmt.Sprintf("The secret stored in the %s definition. ", definitionType),
+		},
+		"b64encoded": schema.BoolAttribute{
+			Optional:    true,
+			Description: "Should the secret be Base64-encoded? If it's not set, then is \"false\"",
+		},
+	}
+}
+
+// definitionTypeResource is the resource implementation shared by the per-type
+// definition resources.
+type definitionTypeResource struct {
+	definitionType
+	client *kmi.KMIRestClient
+}
+
+// Metadata returns the resource type name.
+func (r *definitionTypeResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + r.name + "_definition"
+}
+
+// Schema defines the schema for the resource.
+func (r *definitionTypeResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	// Any change to the type specific attributes updates the definition, which
+	// may change its options and secrets.
+	dependencies := make([]path.Path, 0, len(r.attributes))
+	for name := range r.attributes {
+		dependencies = append(dependencies, path.Root(name))
+	}
+	sort.Slice(dependencies, func(i, j int) bool {
+		return dependencies[i].String() < dependencies[j].String()
+	})
+
+	attributes := map[string]schema.Attribute{
+		"adders": schema.StringAttribute{
+			Optional:    true,
+			Description: "The group name of the admins who will manage the definition permissions. This can be set to the KMI account admin group. ",
+		},
+		"modifiers": schema.StringAttribute{
+			Optional:    true,
+			Description: "The group name of the admins who will manage the definition permissions. This can be set to the KMI account admin group. ",
+		},
+		"readers": schema.StringAttribute{
+			Optional:    true,
+			Description: "The group name of the admins who will read the definition  ",
+		},
+		"name": schema.StringAttribute{
+			Required:    true,
+			Description: "The name of the definition to create. ",
+			Validators:  append([]validator.String{kmiNameValidator()}, r.nameValidators...),
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"collection_name": schema.StringAttribute{
+			Required:    true,
+			Description: "The name of the collection to create. ",
+			Validators:  append([]validator.String{kmiNameValidator()}, r.nameValidators...),
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"last_updated": schema.StringAttribute{
+			Computed:    true,
+			Description: "The KMI modified timestamp of the definition. ",
+		},
+		"options": schema.MapAttribute{
+			Computed:    true,
+			ElementType: types.StringType,
+			Description: "The options of the definition keyed by option name. ",
+			PlanModifiers: []planmodifier.Map{
+				useStateForUnknownUnlessChanged(dependencies...),
+			},
+		},
+		"secret_indexes": schema.ListNestedAttribute{
+			Computed:    true,
+			Description: "The secrets stored under the definition, in the order returned by KMI. ",
+			PlanModifiers: []planmodifier.List{
+				useStateForUnknownUnlessChanged(dependencies...),
+			},
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"index": schema.StringAttribute{
+						Computed:    true,
+						Description: "The index of the secret. ",
+					},
+					"add_date": schema.StringAttribute{
+						Computed:    true,
+						Description: "The date the secret was added. ",
+					},
+					"expire_date": schema.StringAttribute{
+						Computed:    true,
+						Description: "The date the secret expires. ",
+					},
+					"status": schema.StringAttribute{
+						Computed:    true,
+						Description: "The status of the secret. ",
+					},
+				},
+			},
+		},
+	}
+	for name, attribute := range r.attributes {
+		attributes[name] = attribute
+	}
+
+	resp.Schema = schema.Schema{
+		Description: r.description,
+		Attributes:  attributes,
+	}
+}
+
+// ConfigValidators returns the validators of the definition type.
+func (r *definitionTypeResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return r.configValidators
+}
+
+// MoveState migrates kmi_definitions state holding this definition type.
+func (r *definitionTypeResource) MoveState(ctx context.Context) []resource.StateMover {
+	schemaResp := &resource.SchemaResponse{}
+	(&definitionsResource{}).Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	return []resource.StateMover{
+		{
+			SourceSchema: &schemaResp.Schema,
+			StateMover: func(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+				if req.SourceTypeName != "kmi_definitions" {
+					return
+				}
+				if req.SourceState == nil {
+					resp.Diagnostics.AddError(
+						"Unable to Move Definition State",
+						fmt.Sprintf("Could not read kmi_definitions state with schema version %d. Run terraform apply with the kmi_definitions resource to upgrade its state before moving it.", req.SourceSchemaVersion),
+					)
+					return
+				}
+
+				var source definitionResourceModel
+				resp.Diagnostics.Append(req.SourceState.Get(ctx, &source)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				target, ok := r.fromDefinitions(source)
+				if !ok {
+					resp.Diagnostics.AddError(
+						"Unable to Move Definition State",
+						fmt.Sprintf("The kmi_definitions resource %s/%s is not a %s definition.", source.CollectionName.ValueString(), source.DefinitionName.ValueString(), r.name),
+					)
+					return
+				}
+				resp.Diagnostics.Append(resp.TargetState.Set(ctx, target)...)
+			},
+		},
+	}
+}
+
+// ImportState imports a definition by its collection_name/name identifier.
+func (r *definitionTypeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	collectionName, definitionName, ok := strings.Cut(req.ID, "/")
+	if !ok || collectionName == "" || definitionName == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: collection_name/name. Got: %q", req.ID),
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("collection_name"), collectionName)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), definitionName)...)
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *definitionTypeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	mu.Lock()
+	defer mu.Unlock()
+	plan := r.newModel()
+	diags := req.Plan.Get(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.saveDefinition(ctx, nil, plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// ModifyPlan reports ACL groups which do not exist in KMI, and certificates of
+// ssl_cert definitions which are expiring.
+func (r *definitionTypeResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	plan := r.newModel()
+	resp.Diagnostics.Append(req.Plan.Get(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	holder, ok := plan.(sslCertHolder)
+	if r.client != nil {
+		common := plan.common()
+		references := aclGroupReferences(common.Adders, common.Modifiers, common.Readers)
+		if ok {
+			references = append(references, signACLGroupReferences(path.Empty(), holder.sslCert())...)
+		}
+		checkACLGroups(r.client, references, &resp.Diagnostics)
+	}
+
+	if !ok || req.State.Raw.IsNull() {
+		return
+	}
+	state := r.newModel()
+	resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	checkCertificateExpiry(ctx, state.common().DefinitionName.ValueString(), state.(sslCertHolder).sslCert(), plan.(sslCertHolder).sslCert(), time.Now(), &resp.Diagnostics)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *definitionTypeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	state := r.newModel()
+	diags := req.State.Get(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	common := state.common()
+	definitionDetails, err := r.client.GetDefinition(common.CollectionName.ValueString(), common.DefinitionName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading definitions details",
+			"Could not read definitions "+common.DefinitionName.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	imported := common.imported()
+	common.setDefinitionDetails(ctx, definitionDetails, &resp.Diagnostics)
+
+	common.setPermissions(definitionDetails)
+	if m, ok := state.(kmireader); ok {
+		m.ReadResponse(definitionDetails, imported)
+	}
+	if m, ok := state.(kmisecretreader); ok {
+		m.ReadSecret(ctx, r.client, common.CollectionName.ValueString(), common.DefinitionName.ValueString(), definitionDetails, &resp.Diagnostics)
+	}
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *definitionTypeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	mu.Lock()
+	defer mu.Unlock()
+	plan := r.newModel()
+	diags := req.Plan.Get(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state := r.newModel()
+	diags = req.State.Get(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.saveDefinition(ctx, state, plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// saveDefinition creates or updates the definition and its configured secret,
+// then reads back the computed attributes into plan. prior is nil on create.
+func (r *definitionTypeResource) saveDefinition(ctx context.Context, prior definitionTypeModel, plan definitionTypeModel, diags *diag.Diagnostics) {
+	common := plan.common()
+	definition := kmi.KMIDefinition{
+		Adders:    common.Adders.ValueString(),
+		Modifiers: common.Modifiers.ValueString(),
+		Readers:   common.Readers.ValueString(),
+	}
+
+	err := createDefinition(ctx, r.client, common.CollectionName.ValueString(), common.DefinitionName.ValueString(), definition, plan)
+	if err != nil {
+		diags.AddError(
+			"Error creating Definition",
+			"Could not create Definition, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	if prior != nil {
+		err = deleteRemovedOptions(r.client, common.CollectionName.ValueString(), common.DefinitionName.ValueString(), prior, plan)
+		if err != nil {
+			diags.AddError(
+				"Error updating Definition",
+				"Could not remove Definition options, unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	if m, ok := plan.(definitionTypeBlockSecret); ok {
+		err = r.client.CreateBlockSecret(common.CollectionName.ValueString(), common.DefinitionName.ValueString(), m.blockSecret())
+		if err != nil {
+			diags.AddError(
+				"Error creating Block Secret",
+				"Could not create Block Secret, unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	definitionDetails, err := r.client.GetDefinition(common.CollectionName.ValueString(), common.DefinitionName.ValueString())
+	if err != nil {
+		diags.AddError(
+			"Error Reading definitions details",
+			"Could not read definitions "+common.DefinitionName.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	common.setDefinitionDetails(ctx, definitionDetails, diags)
+	if m, ok := plan.(kmisecretreader); ok {
+		m.ReadSecret(ctx, r.client, common.CollectionName.ValueString(), common.DefinitionName.ValueString(), definitionDetails, diags)
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *definitionTypeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	mu.Lock()
+	defer mu.Unlock()
+	state := r.newModel()
+	diags := req.State.Get(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	common := state.common()
+	err := r.client.DeleteDefinition(common.CollectionName.ValueString(), common.DefinitionName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting Definitions",
+			"Could not delete Definitions, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+func (r *definitionTypeResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*kmi.KMIRestClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *KMIRestClient., got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (m *definitionCommonModel) common() *definitionCommonModel {
+	return m
+}
+
+type sslCertDefinitionModel struct {
+	definitionCommonModel
+	SSLCert
+}
+
+type azureSPDefinitionModel struct {
+	definitionCommonModel
+	AzureSP
+}
+
+type symmetricKeyDefinitionModel struct {
+	definitionCommonModel
+	SymetricKey
+}
+
+type asymmetricKeyDefinitionModel struct {
+	definitionCommonModel
+	AsymmetricKey
+}
+
+type sshKeyDefinitionModel struct {
+	definitionCommonModel
+	SSHKey
+}
+
+type passwordDefinitionModel struct {
+	definitionCommonModel
+	Password
+}
+
+type opaqueDefinitionModel struct {
+	definitionCommonModel
+	Value      types.String `tfsdk:"value"`
+	B64Encoded types.Bool   `tfsdk:"b64encoded"`
+}
+
+func (m *opaqueDefinitionModel) RequestPayload(definition kmi.KMIDefinition) (kmi.KMIDefinition, error) {
+	return Opaque{}.RequestPayload(definition)
+}
+
+func (m *opaqueDefinitionModel) blockSecret() kmi.BlockSecret {
+	return newBlockSecret("opaque", m.Value.ValueString(), m.B64Encoded.ValueBool())
+}
+
+type transparentDefinitionModel struct {
+	definitionCommonModel
+	Value      types.String `tfsdk:"value"`
+	B64Encoded types.Bool   `tfsdk:"b64encoded"`
+}
+
+func (m *transparentDefinitionModel) RequestPayload(definition kmi.KMIDefinition) (kmi.KMIDefinition, error) {
+	return Transparent{}.RequestPayload(definition)
+}
+
+func (m *transparentDefinitionModel) blockSecret() kmi.BlockSecret {
+	return newBlockSecret("transparent", m.Value.ValueString(), m.B64Encoded.ValueBool())
+}
+
+// newBlockSecret builds the payload storing a configured secret under a definition.
+func newBlockSecret(name string, text string, b64encoded bool) kmi.BlockSecret {
+	var secret kmi.BlockSecret
+	secret.Block.Name = name
+	secret.Block.Text = text
+	secret.Block.B64Encoded = boolStr(b64encoded)
+	return secret
+}
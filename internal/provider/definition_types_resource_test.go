@@ -0,0 +1,289 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestDefinitionTypeResourceSchema(t *testing.T) {
+	t.Parallel()
+
+	for name, newResource := range map[string]func() fwresource.Resource{
+		"ssl_cert":       NewSSLCertDefinitionResource,
+		"azure_sp":       NewAzureSPDefinitionResource,
+		"symmetric_key":  NewSymmetricKeyDefinitionResource,
+		"asymmetric_key": NewAsymmetricKeyDefinitionResource,
+		"ssh_key":        NewSSHKeyDefinitionResource,
+		"password":       NewPasswordDefinitionResource,
+		"opaque":         NewOpaqueDefinitionResource,
+		"transparent":    NewTransparentDefinitionResource,
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			schemaResponse := &fwresource.SchemaResponse{}
+			newResource().Schema(ctx, fwresource.SchemaRequest{}, schemaResponse)
+			if schemaResponse.Diagnostics.HasError() {
+				t.Fatalf("Schema method diagnostics: %+v", schemaResponse.Diagnostics)
+			}
+
+			diagnostics := schemaResponse.Schema.ValidateImplementation(ctx)
+			if diagnostics.HasError() {
+				t.Fatalf("Schema validation diagnostics: %+v", diagnostics)
+			}
+		})
+	}
+}
+
+// testMoveDefinitionState runs the state mover of r against kmi_definitions state
+// built from values.
+func testMoveDefinitionState(t *testing.T, r fwresource.Resource, sourceTypeName string, values map[string]tftypes.Value) *fwresource.MoveStateResponse {
+	ctx := context.Background()
+	mover, ok := r.(fwresource.ResourceWithMoveState)
+	if !ok {
+		t.Fatal("resource does not implement ResourceWithMoveState")
+	}
+
+	source := testResourceConfig(t, NewDefinitionsResource(), values)
+	targetSchema := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, targetSchema)
+
+	req := fwresource.MoveStateRequest{
+		SourceTypeName:      sourceTypeName,
+		SourceSchemaVersion: source.Schema.GetVersion(),
+		SourceState:         &tfsdk.State{Schema: source.Schema, Raw: source.Raw},
+	}
+	resp := &fwresource.MoveStateResponse{
+		TargetState: tfsdk.State{
+			Schema: targetSchema.Schema,
+			Raw:    tftypes.NewValue(targetSchema.Schema.Type().TerraformType(ctx), nil),
+		},
+	}
+	mover.MoveState(ctx)[0].StateMover(ctx, req, resp)
+	return resp
+}
+
+func TestDefinitionTypeResourceMoveState(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	values := map[string]tftypes.Value{
+		"name":            tftypes.NewValue(tftypes.String, "cert"),
+		"collection_name": tftypes.NewValue(tftypes.String, "col"),
+		"ssl_cert": testSSLCertValue(t, map[string]tftypes.Value{
+			"auto_generate": tftypes.NewValue(tftypes.Bool, true),
+			"cn":            tftypes.NewValue(tftypes.String, "example.com"),
+		}),
+	}
+
+	resp := testMoveDefinitionState(t, NewSSLCertDefinitionResource(), "kmi_definitions", values)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("MoveState() diagnostics = %v", resp.Diagnostics)
+	}
+	var name, cn types.String
+	resp.Diagnostics.Append(resp.TargetState.GetAttribute(ctx, path.Root("name"), &name)...)
+	resp.Diagnostics.Append(resp.TargetState.GetAttribute(ctx, path.Root("cn"), &cn)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("GetAttribute() diagnostics = %v", resp.Diagnostics)
+	}
+	if name.ValueString() != "cert" || cn.ValueString() != "example.com" {
+		t.Errorf("MoveState() name = %v, cn = %v", name, cn)
+	}
+
+	resp = testMoveDefinitionState(t, NewSymmetricKeyDefinitionResource(), "kmi_definitions", values)
+	if !resp.Diagnostics.HasError() {
+		t.Error("MoveState() of an ssl_cert definition into kmi_symmetric_key_definition succeeded")
+	}
+
+	resp = testMoveDefinitionState(t, NewSSLCertDefinitionResource(), "kmi_collections", values)
+	if resp.Diagnostics.HasError() || !resp.TargetState.Raw.IsNull() {
+		t.Errorf("MoveState() from another resource type = %v, want no-op", resp.TargetState.Raw)
+	}
+}
+
+func TestDefinitionTypeResourceImportState(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	r, ok := NewSymmetricKeyDefinitionResource().(fwresource.ResourceWithImportState)
+	if !ok {
+		t.Fatal("resource does not implement ResourceWithImportState")
+	}
+	schemaResponse := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResponse)
+	newResponse := func() *fwresource.ImportStateResponse {
+		return &fwresource.ImportStateResponse{
+			State: tfsdk.State{
+				Schema: schemaResponse.Schema,
+				Raw:    tftypes.NewValue(schemaResponse.Schema.Type().TerraformType(ctx), nil),
+			},
+		}
+	}
+
+	resp := newResponse()
+	r.ImportState(ctx, fwresource.ImportStateRequest{ID: "col/key"}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("ImportState() diagnostics = %v", resp.Diagnostics)
+	}
+	var collectionName, name types.String
+	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("collection_name"), &collectionName)...)
+	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("name"), &name)...)
+	if collectionName.ValueString() != "col" || name.ValueString() != "key" {
+		t.Errorf("ImportState() collection_name = %v, name = %v", collectionName, name)
+	}
+
+	for _, id := range []string{"key", "col/", "/key"} {
+		resp = newResponse()
+		r.ImportState(ctx, fwresource.ImportStateRequest{ID: id}, resp)
+		if !resp.Diagnostics.HasError() {
+			t.Errorf("ImportState(%q) succeeded, want error", id)
+		}
+	}
+}
+
+// testReadDefinition refreshes state with r against a KMI returning definition
+// for col/def.
+func testReadDefinition(t *testing.T, r fwresource.Resource, state tfsdk.State, definition string) tfsdk.State {
+	ctx := context.Background()
+	client := testKMIClient(t, http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/definition/Col=col/Def=def" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, definition)
+	}))
+	configureResp := &fwresource.ConfigureResponse{}
+	r.(fwresource.ResourceWithConfigure).Configure(ctx, fwresource.ConfigureRequest{ProviderData: client}, configureResp)
+	if configureResp.Diagnostics.HasError() {
+		t.Fatalf("Configure() diagnostics = %v", configureResp.Diagnostics)
+	}
+
+	resp := &fwresource.ReadResponse{State: state}
+	r.Read(ctx, fwresource.ReadRequest{State: state}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read() diagnostics = %v", resp.Diagnostics)
+	}
+	return resp.State
+}
+
+func TestDefinitionResourcesImportRead(t *testing.T) {
+	t.Parallel()
+
+	sslCert := `<definition name="def" type="ssl_cert" modified="355396416">
+	<auto_generate>True</auto_generate>
+	<expire_period>1 years</expire_period>
+	<refresh_period>6 months</refresh_period>
+	<option name="cn">web.example.com</option>
+	<option name="subj_alt_names">web.example.com,web.example.net</option>
+	<option name="ca_name">corp-ca</option>
+	<option name="signacl:web">true</option>
+	<option name="key_usage">critical</option>
+</definition>`
+	password := `<definition name="def" type="password" modified="355396416">
+	<auto_generate>True</auto_generate>
+	<option name="length">24</option>
+	<option name="charset">hex</option>
+</definition>`
+	sans := types.SetValueMust(types.StringType, []attr.Value{
+		types.StringValue("web.example.com"),
+		types.StringValue("web.example.net"),
+	})
+	extraOptions := types.MapValueMust(types.StringType, map[string]attr.Value{
+		"key_usage": types.StringValue("critical"),
+	})
+
+	tests := []struct {
+		name        string
+		newResource func() fwresource.Resource
+		definition  string
+		want        map[string]attr.Value
+	}{
+		{
+			name:        "kmi_ssl_cert_definition",
+			newResource: NewSSLCertDefinitionResource,
+			definition:  sslCert,
+			want: map[string]attr.Value{
+				"cn":             types.StringValue("web.example.com"),
+				"subj_alt_names": sans,
+				"ca_name":        types.StringValue("corp-ca"),
+				"expire_period":  types.StringValue("1 years"),
+				"refresh_period": types.StringValue("6 months"),
+				"extra_options":  extraOptions,
+			},
+		},
+		{
+			name:        "kmi_password_definition",
+			newResource: NewPasswordDefinitionResource,
+			definition:  password,
+			want: map[string]attr.Value{
+				"length":  types.Int64Value(24),
+				"charset": types.StringValue("hex"),
+			},
+		},
+		{
+			name:        "kmi_definitions",
+			newResource: NewDefinitionsResource,
+			definition:  sslCert,
+			want: map[string]attr.Value{
+				"ssl_cert.cn":             types.StringValue("web.example.com"),
+				"ssl_cert.subj_alt_names": sans,
+				"ssl_cert.expire_period":  types.StringValue("1 years"),
+				"ssl_cert.extra_options":  extraOptions,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			r := tt.newResource()
+			schemaResponse := &fwresource.SchemaResponse{}
+			r.Schema(ctx, fwresource.SchemaRequest{}, schemaResponse)
+			importResp := &fwresource.ImportStateResponse{
+				State: tfsdk.State{
+					Schema: schemaResponse.Schema,
+					Raw:    tftypes.NewValue(schemaResponse.Schema.Type().TerraformType(ctx), nil),
+				},
+			}
+			r.(fwresource.ResourceWithImportState).ImportState(ctx, fwresource.ImportStateRequest{ID: "col/def"}, importResp)
+			if importResp.Diagnostics.HasError() {
+				t.Fatalf("ImportState() diagnostics = %v", importResp.Diagnostics)
+			}
+
+			imported := testReadDefinition(t, r, importResp.State, tt.definition)
+			for name, want := range tt.want {
+				p := path.Empty()
+				for _, step := range strings.Split(name, ".") {
+					p = p.AtName(step)
+				}
+				var got attr.Value
+				if diags := imported.GetAttribute(ctx, p, &got); diags.HasError() {
+					t.Fatalf("GetAttribute(%s) diagnostics = %v", p, diags)
+				}
+				if !got.Equal(want) {
+					t.Errorf("%s = %v, want %v", p, got, want)
+				}
+			}
+
+			// Refreshing the imported state keeps every attribute.
+			refreshed := testReadDefinition(t, r, imported, tt.definition)
+			if !refreshed.Raw.Equal(imported.Raw) {
+				t.Errorf("Read() after import = %v, want %v", refreshed.Raw, imported.Raw)
+			}
+		})
+	}
+}
@@ -1,26 +1,37 @@
 package provider
 
 import (
-	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
-	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"terraform-provider-kmi/internal/kmi"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource              = &definitionsResource{}
-	_ resource.ResourceWithConfigure = &definitionsResource{}
+	_ resource.Resource                     = &definitionsResource{}
+	_ resource.ResourceWithConfigure        = &definitionsResource{}
+	_ resource.ResourceWithConfigValidators = &definitionsResource{}
+	_ resource.ResourceWithUpgradeState     = &definitionsResource{}
+	_ resource.ResourceWithModifyPlan       = &definitionsResource{}
+	_ resource.ResourceWithImportState      = &definitionsResource{}
 )
 
 // NewOrderResource is a helper function to simplify the provider implementation.
@@ -42,6 +53,7 @@ func (r *definitionsResource) Metadata(_ context.Context, req resource.MetadataR
 // Schema defines the schema for the resource.
 func (r *definitionsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 3,
 		Attributes: map[string]schema.Attribute{
 			"adders": schema.StringAttribute{
 				Optional:    true,
@@ -58,100 +70,88 @@ func (r *definitionsResource) Schema(_ context.Context, _ resource.SchemaRequest
 			"name": schema.StringAttribute{
 				Required:    true,
 				Description: "The name of the definition to create. ",
+				Validators: []validator.String{
+					kmiNameValidator(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"collection_name": schema.StringAttribute{
 				Required:    true,
 				Description: "The name of the collection to create. ",
+				Validators: []validator.String{
+					kmiNameValidator(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"last_updated": schema.StringAttribute{
-				Computed: true,
+				Computed:    true,
+				Description: "The KMI modified timestamp of the definition. ",
 			},
 			"ssl_cert": schema.SingleNestedAttribute{
-				Attributes: map[string]schema.Attribute{
-					"auto_generate": schema.BoolAttribute{
-						Required:    true,
-						Description: "Auto generate the SSL certificate. ",
-					},
-					"expire_period": schema.StringAttribute{
-						Optional:    true,
-						Description: "The expire period for the symmetric key. ",
-					},
-					"refresh_period": schema.StringAttribute{
-						Optional:    true,
-						Description: "The refresh period for the symmetric key. ",
-					},
-					"issuer": schema.StringAttribute{
-						Optional:    true,
-						Description: "The issuer for the SSL certificate. ",
-					},
-					// Error from KMI side: is_ca must not exist or have an integral value
-					// is_ca has to be 1 if enabled, cannot be a true/false boolean
-					"is_ca": schema.Int64Attribute{
-						Optional:    true,
-						Description: "Is the SSL certificate a CA. ",
-					},
-					"subject": schema.StringAttribute{
-						Optional:    true,
-						Description: "Subject Name of the SSL certificate. ",
-					},
-					"cn": schema.StringAttribute{
-						Optional:    true,
-						Description: "Common Name of the SSL certificate. ",
-					},
-					"subj_alt_names": schema.StringAttribute{
-						Optional:    true,
-						Description: "Subject Alternative Names of the SSL certificate. ",
-					},
-					"subj_alt_uris": schema.StringAttribute{
-						Optional:    true,
-						Description: "Subject Alternative URIs of the SSL certificate. ",
-					},
-					"ca_name": schema.StringAttribute{
-						Optional:    true,
-						Description: "KMI path to the template used to sign the certificate by the CA.",
-					},
-					"signacl": schema.StringAttribute{
-						Optional:    true,
-						Description: "Collection that is eligible to sign the certificate. Can be used for CA definition setup.",
-					},
-					"signacldomain": schema.StringAttribute{
-						Optional:    true,
-						Description: "Much like a signacl rule, it restricts signing to the named collection. However, it has the additional restriction of only applying to a particular domain name or wildcarded domain (denoted by a domain starting with '*.' ). Can be used for CA definition setup.",
-					},
-					"signaclgroup": schema.StringAttribute{
-						Optional:    true,
-						Description: "Group that is eligible to sign the certificate. Can be used for CA definition setup.",
-					},
-				},
+				Attributes:  sslCertAttributes(),
 				Optional:    true,
 				Description: "The SSL certificate to create. ",
+				PlanModifiers: []planmodifier.Object{
+					requiresReplaceIfDefinitionTypeObject(),
+				},
 			},
 			"azure_sp": schema.SingleNestedAttribute{
-				Attributes: map[string]schema.Attribute{
-					"auto_generate": schema.BoolAttribute{
-						Required: true,
-					},
-				},
+				Attributes:  azureSPAttributes(),
 				Optional:    true,
 				Description: "The Azure Service Principal to create. ",
+				PlanModifiers: []planmodifier.Object{
+					requiresReplaceIfDefinitionTypeObject(),
+				},
+			},
+			"asymmetric_key": schema.SingleNestedAttribute{
+				Attributes:  asymmetricKeyAttributes(),
+				Optional:    true,
+				Description: "The RSA or EC key pair to create. ",
+				PlanModifiers: []planmodifier.Object{
+					requiresReplaceIfDefinitionTypeObject(),
+				},
+			},
+			"ssh_key": schema.SingleNestedAttribute{
+				Attributes:  sshKeyAttributes(),
+				Optional:    true,
+				Description: "The SSH key pair to create. ",
+				PlanModifiers: []planmodifier.Object{
+					requiresReplaceIfDefinitionTypeObject(),
+				},
 			},
-			"options": schema.ListNestedAttribute{
+			"password": schema.SingleNestedAttribute{
+				Attributes:  passwordAttributes(),
+				Optional:    true,
+				Description: "The auto-generated password to create. ",
+				PlanModifiers: []planmodifier.Object{
+					requiresReplaceIfDefinitionTypeObject(),
+				},
+			},
+			"options": schema.MapAttribute{
 				Computed:    true,
-				Description: "The list of options for the definition. ",
-				NestedObject: schema.NestedAttributeObject{
-					Attributes: map[string]schema.Attribute{
-						"name": schema.StringAttribute{
-							Computed: true,
-						},
-						"value": schema.StringAttribute{
-							Computed: true,
-						},
-					},
+				ElementType: types.StringType,
+				Description: "The options of the definition keyed by option name. ",
+				PlanModifiers: []planmodifier.Map{
+					useStateForUnknownUnlessChanged(
+						path.Root("ssl_cert"),
+						path.Root("azure_sp"),
+						path.Root("symmetric_key"),
+						path.Root("asymmetric_key"),
+						path.Root("ssh_key"),
+						path.Root("password"),
+					),
 				},
 			},
 			"opaque": schema.StringAttribute{
 				Optional:    true,
 				Description: "The Opaque definition to create. ",
+				PlanModifiers: []planmodifier.String{
+					requiresReplaceIfDefinitionTypeString(),
+				},
 			},
 			"b64encoded": schema.BoolAttribute{
 				Optional:    true,
@@ -160,36 +160,538 @@ func (r *definitionsResource) Schema(_ context.Context, _ resource.SchemaRequest
 			"transparent": schema.StringAttribute{
 				Optional:    true,
 				Description: "The Transparent definition to create. ",
+				PlanModifiers: []planmodifier.String{
+					requiresReplaceIfDefinitionTypeString(),
+				},
 			},
-			"secret_indexes": schema.StringAttribute{
+			"secret_indexes": schema.ListNestedAttribute{
 				Computed:    true,
-				Description: "The list of secret indexes for the definition. ",
+				Description: "The secrets stored under the definition, in the order returned by KMI. ",
+				PlanModifiers: []planmodifier.List{
+					useStateForUnknownUnlessChanged(
+						path.Root("ssl_cert"),
+						path.Root("azure_sp"),
+						path.Root("symmetric_key"),
+						path.Root("asymmetric_key"),
+						path.Root("ssh_key"),
+						path.Root("password"),
+						path.Root("opaque"),
+						path.Root("transparent"),
+						path.Root("b64encoded"),
+					),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"index": schema.StringAttribute{
+							Computed:    true,
+							Description: "The index of the secret. ",
+						},
+						"add_date": schema.StringAttribute{
+							Computed:    true,
+							Description: "The date the secret was added. ",
+						},
+						"expire_date": schema.StringAttribute{
+							Computed:    true,
+							Description: "The date the secret expires. ",
+						},
+						"status": schema.StringAttribute{
+							Computed:    true,
+							Description: "The status of the secret. ",
+						},
+					},
+				},
 			},
 			"symmetric_key": schema.SingleNestedAttribute{
-				Attributes: map[string]schema.Attribute{
-					"auto_generate": schema.BoolAttribute{
-						Required:    true,
-						Description: "Auto generate the symmetric key. ",
-					},
-					"expire_period": schema.StringAttribute{
-						Required:    true,
-						Description: "The expire period for the symmetric key. ",
-					},
-					"refresh_period": schema.StringAttribute{
-						Required:    true,
-						Description: "The refresh period for the symmetric key. ",
-					},
-					"key_size_bytes": schema.Int64Attribute{
-						Optional:    true,
-						Description: "The key size in bytes for the symmetric key. ",
-					},
+				Attributes: symmetricKeyAttributes(),
+				Optional:   true,
+				PlanModifiers: []planmodifier.Object{
+					requiresReplaceIfDefinitionTypeObject(),
 				},
-				Optional: true,
 			},
 		},
 	}
 }
 
+// sslCertAttributes returns the attributes of the SSL certificate definition type.
+func sslCertAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"extra_options": schema.MapAttribute{
+			Optional:    true,
+			ElementType: types.StringType,
+			Description: "Additional KMI options passed through to the definition as-is, keyed by option name. ",
+			Validators: []validator.Map{
+				reservedKeysMapValidator(sslCertOptionNames, sslCertOptionPrefixes),
+			},
+		},
+		"auto_generate": schema.BoolAttribute{
+			Required:    true,
+			Description: "Auto generate the SSL certificate. ",
+		},
+		"expire_period": schema.StringAttribute{
+			Optional:    true,
+			Description: "The expire period for the SSL certificate. ",
+			Validators: []validator.String{
+				kmiPeriodValidator(),
+			},
+		},
+		"refresh_period": schema.StringAttribute{
+			Optional:    true,
+			Description: "The refresh period for the SSL certificate. ",
+			Validators: []validator.String{
+				kmiPeriodValidator(),
+			},
+		},
+		"issuer": schema.StringAttribute{
+			Optional:    true,
+			Description: "The issuer for the SSL certificate. ",
+		},
+		// Error from KMI side: is_ca must not exist or have an integral value
+		// is_ca has to be 1 if enabled, cannot be a true/false boolean
+		"is_ca": schema.Int64Attribute{
+			Optional:    true,
+			Description: "Is the SSL certificate a CA. ",
+		},
+		"subject": schema.StringAttribute{
+			Optional:    true,
+			Description: "Subject Name of the SSL certificate. ",
+		},
+		"cn": schema.StringAttribute{
+			Optional:    true,
+			Description: "Common Name of the SSL certificate. ",
+		},
+		"subj_alt_names": schema.SetAttribute{
+			Optional:    true,
+			ElementType: types.StringType,
+			Description: "Subject Alternative Names of the SSL certificate. ",
+			Validators: []validator.Set{
+				dnsNamesValidator(false),
+			},
+		},
+		"subj_alt_uris": schema.SetAttribute{
+			Optional:    true,
+			ElementType: types.StringType,
+			Description: "Subject Alternative URIs of the SSL certificate. ",
+			Validators: []validator.Set{
+				urisValidator(false),
+			},
+		},
+		"ca_name": schema.StringAttribute{
+			Optional:    true,
+			Description: "KMI path to the template used to sign the certificate by the CA.",
+		},
+		"signacl": schema.SetAttribute{
+			Optional:    true,
+			ElementType: types.StringType,
+			Description: "Collections that are eligible to sign the certificate. Can be used for CA definition setup.",
+		},
+		"signacldomain": schema.SetAttribute{
+			Optional:    true,
+			ElementType: types.StringType,
+			Description: "Much like signacl rules, they restrict signing to the named collections. However, they have the additional restriction of only applying to a particular domain name or wildcarded domain (denoted by a domain starting with '*.' ). Can be used for CA definition setup.",
+		},
+		"signaclgroup": schema.SetAttribute{
+			Optional:    true,
+			ElementType: types.StringType,
+			Description: "Groups that are eligible to sign the certificate. Can be used for CA definition setup.",
+		},
+		"expiry_warning_window": schema.StringAttribute{
+			Optional:    true,
+			Description: "Warn during plan when the certificate expires within this KMI period, such as \"30 days\". Not sent to KMI. ",
+			Validators: []validator.String{
+				kmiPeriodValidator(),
+			},
+		},
+		"error_if_expired": schema.BoolAttribute{
+			Optional:    true,
+			Description: "Fail the plan when the certificate has already expired. Not sent to KMI. ",
+		},
+		"certificate": certificateAttribute(),
+	}
+}
+
+// azureSPAttributes returns the attributes of the Azure Service Principal definition type.
+func azureSPAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"extra_options": schema.MapAttribute{
+			Optional:    true,
+			ElementType: types.StringType,
+			Description: "Additional KMI options passed through to the definition as-is, keyed by option name. ",
+		},
+		"auto_generate": schema.BoolAttribute{
+			Required: true,
+		},
+	}
+}
+
+// asymmetricKeyAttributes returns the attributes of the asymmetric key pair definition type.
+func asymmetricKeyAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"extra_options": schema.MapAttribute{
+			Optional:    true,
+			ElementType: types.StringType,
+			Description: "Additional KMI options passed through to the definition as-is, keyed by option name. ",
+			Validators: []validator.Map{
+				reservedKeysMapValidator(asymmetricKeyOptionNames, nil),
+			},
+		},
+		"auto_generate": schema.BoolAttribute{
+			Required:    true,
+			Description: "Auto generate the key pair. ",
+		},
+		"expire_period": schema.StringAttribute{
+			Optional:    true,
+			Description: "The expire period for the key pair. ",
+			Validators: []validator.String{
+				kmiPeriodValidator(),
+			},
+		},
+		"refresh_period": schema.StringAttribute{
+			Optional:    true,
+			Description: "The refresh period for the key pair. ",
+			Validators: []validator.String{
+				kmiPeriodValidator(),
+			},
+		},
+		"key_type": schema.StringAttribute{
+			Required:    true,
+			Description: "The type of the key pair, rsa or ec. ",
+			Validators: []validator.String{
+				stringOneOf("rsa", "ec"),
+			},
+		},
+		"key_size": schema.Int64Attribute{
+			Optional:    true,
+			Description: "The RSA modulus size in bits. Required for rsa keys, not valid for ec keys. ",
+			Validators: []validator.Int64{
+				int64Between(2048, 8192),
+			},
+		},
+		"curve": schema.StringAttribute{
+			Optional:    true,
+			Description: "The elliptic curve of the key. Required for ec keys, not valid for rsa keys. ",
+			Validators: []validator.String{
+				stringOneOf("secp256r1", "secp384r1", "secp521r1"),
+			},
+		},
+	}
+}
+
+// sshKeyAttributes returns the attributes of the SSH key pair definition type.
+func sshKeyAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"extra_options": schema.MapAttribute{
+			Optional:    true,
+			ElementType: types.StringType,
+			Description: "Additional KMI options passed through to the definition as-is, keyed by option name. ",
+			Validators: []validator.Map{
+				reservedKeysMapValidator(sshKeyOptionNames, nil),
+			},
+		},
+		"auto_generate": schema.BoolAttribute{
+			Required:    true,
+			Description: "Auto generate the SSH key pair. ",
+		},
+		"expire_period": schema.StringAttribute{
+			Optional:    true,
+			Description: "The expire period for the SSH key pair. ",
+			Validators: []validator.String{
+				kmiPeriodValidator(),
+			},
+		},
+		"refresh_period": schema.StringAttribute{
+			Optional:    true,
+			Description: "The refresh period for the SSH key pair. ",
+			Validators: []validator.String{
+				kmiPeriodValidator(),
+			},
+		},
+		"key_type": schema.StringAttribute{
+			Required:    true,
+			Description: "The type of the SSH key pair, rsa, ecdsa or ed25519. ",
+			Validators: []validator.String{
+				stringOneOf("rsa", "ecdsa", "ed25519"),
+			},
+		},
+		"key_size": schema.Int64Attribute{
+			Optional:    true,
+			Description: "The key size in bits. Ignored for ed25519 keys. ",
+			Validators: []validator.Int64{
+				int64Between(256, 8192),
+			},
+		},
+	}
+}
+
+// passwordAttributes returns the attributes of the password definition type.
+func passwordAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"extra_options": schema.MapAttribute{
+			Optional:    true,
+			ElementType: types.StringType,
+			Description: "Additional KMI options passed through to the definition as-is, keyed by option name. ",
+			Validators: []validator.Map{
+				reservedKeysMapValidator(passwordOptionNames, nil),
+			},
+		},
+		"auto_generate": schema.BoolAttribute{
+			Required:    true,
+			Description: "Auto generate the password. ",
+		},
+		"expire_period": schema.StringAttribute{
+			Optional:    true,
+			Description: "The expire period for the password. ",
+			Validators: []validator.String{
+				kmiPeriodValidator(),
+			},
+		},
+		"refresh_period": schema.StringAttribute{
+			Optional:    true,
+			Description: "The refresh period for the password. ",
+			Validators: []validator.String{
+				kmiPeriodValidator(),
+			},
+		},
+		"length": schema.Int64Attribute{
+			Optional:    true,
+			Description: "The length of the generated password. ",
+			Validators: []validator.Int64{
+				int64Between(8, 1024),
+			},
+		},
+		"charset": schema.StringAttribute{
+			Optional:    true,
+			Description: "The characters the generated password is made of: alphanumeric, alpha, numeric, hex or printable. ",
+			Validators: []validator.String{
+				stringOneOf("alphanumeric", "alpha", "numeric", "hex", "printable"),
+			},
+		},
+	}
+}
+
+// symmetricKeyAttributes returns the attributes of the symmetric key definition type.
+func symmetricKeyAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"extra_options": schema.MapAttribute{
+			Optional:    true,
+			ElementType: types.StringType,
+			Description: "Additional KMI options passed through to the definition as-is, keyed by option name. ",
+			Validators: []validator.Map{
+				reservedKeysMapValidator(symmetricKeyOptionNames, nil),
+			},
+		},
+		"auto_generate": schema.BoolAttribute{
+			Required:    true,
+			Description: "Auto generate the symmetric key. ",
+		},
+		"expire_period": schema.StringAttribute{
+			Required:    true,
+			Description: "The expire period for the symmetric key. ",
+			Validators: []validator.String{
+				kmiPeriodValidator(),
+			},
+		},
+		"refresh_period": schema.StringAttribute{
+			Required:    true,
+			Description: "The refresh period for the symmetric key. ",
+			Validators: []validator.String{
+				kmiPeriodValidator(),
+			},
+		},
+		"key_size_bytes": schema.Int64Attribute{
+			Optional:    true,
+			Description: "The key size in bytes for the symmetric key. ",
+		},
+	}
+}
+
+// ConfigValidators ensures exactly one definition type is configured, that
+// mutually exclusive SSL certificate options are not set together and that
+// azure_sp definitions use lower case names.
+func (r *definitionsResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	validators := []resource.ConfigValidator{
+		exactlyOneOfValidator{
+			paths: []path.Path{
+				path.Root("ssl_cert"),
+				path.Root("azure_sp"),
+				path.Root("symmetric_key"),
+				path.Root("asymmetric_key"),
+				path.Root("ssh_key"),
+				path.Root("password"),
+				path.Root("opaque"),
+				path.Root("transparent"),
+			},
+		},
+		stringsValidWhenValidator{
+			when: path.Root("azure_sp"),
+			paths: []path.Path{
+				path.Root("collection_name"),
+				path.Root("name"),
+			},
+			validator: kmiLowerNameValidator(),
+		},
+	}
+	validators = append(validators, sslCertConfigValidators(path.Root("ssl_cert"))...)
+	validators = append(validators, asymmetricKeyConfigValidators(path.Root("asymmetric_key"))...)
+	return validators
+}
+
+// sslCertConfigValidators rejects mutually exclusive SSL certificate options
+// configured under block.
+func sslCertConfigValidators(block path.Path) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		conflictingValidator{
+			paths: []path.Path{
+				block.AtName("issuer"),
+				block.AtName("is_ca"),
+			},
+		},
+		conflictingValidator{
+			paths: []path.Path{
+				block.AtName("subject"),
+				block.AtName("cn"),
+			},
+		},
+	}
+}
+
+// asymmetricKeyConfigValidators rejects mutually exclusive asymmetric key options
+// configured under block, and requires the key_size of rsa keys and the curve
+// of ec keys.
+func asymmetricKeyConfigValidators(block path.Path) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		conflictingValidator{
+			paths: []path.Path{
+				block.AtName("key_size"),
+				block.AtName("curve"),
+			},
+		},
+		attributesForValueValidator{
+			path:      block.AtName("key_type"),
+			value:     "rsa",
+			required:  []path.Path{block.AtName("key_size")},
+			forbidden: []path.Path{block.AtName("curve")},
+		},
+		attributesForValueValidator{
+			path:      block.AtName("key_type"),
+			value:     "ec",
+			required:  []path.Path{block.AtName("curve")},
+			forbidden: []path.Path{block.AtName("key_size")},
+		},
+	}
+}
+
+// UpgradeState migrates state written by earlier versions of the schema.
+func (r *definitionsResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// Version 0 stored options as a name/value list and secret_indexes as a
+		// comma joined string. Both are computed, so they are cleared here and
+		// repopulated by the next refresh.
+		0: {
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				upgradeDefinitionState(req, resp, func(rawState map[string]any) {
+					rawState["options"] = nil
+					rawState["secret_indexes"] = nil
+					upgradeSignACLs(rawState)
+					upgradeSubjectAltNames(rawState)
+				})
+			},
+		},
+		// Version 1 stored a single signacl, signacldomain and signaclgroup.
+		1: {
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				upgradeDefinitionState(req, resp, func(rawState map[string]any) {
+					upgradeSignACLs(rawState)
+					upgradeSubjectAltNames(rawState)
+				})
+			},
+		},
+		// Version 2 stored subj_alt_names and subj_alt_uris as comma delimited strings.
+		2: {
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				upgradeDefinitionState(req, resp, upgradeSubjectAltNames)
+			},
+		},
+	}
+}
+
+// upgradeDefinitionState rewrites the raw JSON state of an earlier schema version.
+func upgradeDefinitionState(req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse, upgrade func(map[string]any)) {
+	var rawState map[string]any
+	err := json.Unmarshal(req.RawState.JSON, &rawState)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Upgrading Definition State",
+			"Could not parse prior state, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	upgrade(rawState)
+
+	upgraded, err := json.Marshal(rawState)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Upgrading Definition State",
+			"Could not encode upgraded state, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	resp.DynamicValue = &tfprotov6.DynamicValue{JSON: upgraded}
+}
+
+// upgradeSignACLs converts the single valued ssl_cert ACL attributes into sets.
+func upgradeSignACLs(rawState map[string]any) {
+	sslCert, ok := rawState["ssl_cert"].(map[string]any)
+	if !ok {
+		return
+	}
+	for _, name := range []string{"signacl", "signacldomain", "signaclgroup"} {
+		if value, ok := sslCert[name].(string); ok {
+			sslCert[name] = []any{value}
+		}
+	}
+}
+
+// upgradeSubjectAltNames converts the comma delimited ssl_cert SAN attributes into sets.
+func upgradeSubjectAltNames(rawState map[string]any) {
+	sslCert, ok := rawState["ssl_cert"].(map[string]any)
+	if !ok {
+		return
+	}
+	for _, name := range []string{"subj_alt_names", "subj_alt_uris"} {
+		if value, ok := sslCert[name].(string); ok {
+			sslCert[name] = splitCommaList(value)
+		}
+	}
+}
+
+// ModifyPlan reports ACL groups which do not exist in KMI, and certificates of
+// ssl_cert definitions which are expiring.
+func (r *definitionsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	var plan definitionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if r.client != nil {
+		references := aclGroupReferences(plan.Adders, plan.Modifiers, plan.Readers)
+		references = append(references, signACLGroupReferences(path.Root("ssl_cert"), plan.SSLCert)...)
+		checkACLGroups(r.client, references, &resp.Diagnostics)
+	}
+
+	if req.State.Raw.IsNull() {
+		return
+	}
+	var state definitionResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	checkCertificateExpiry(ctx, state.DefinitionName.ValueString(), state.SSLCert, plan.SSLCert, time.Now(), &resp.Diagnostics)
+}
+
 // Can be removed once KMI API bug failing parallel requests is resolved (KMISUP-1541).
 var mu sync.Mutex
 
@@ -213,44 +715,32 @@ func (r *definitionsResource) Create(ctx context.Context, req resource.CreateReq
 	var err error
 	if plan.SSLCert != nil {
 		tflog.Info(ctx, "SSl cert is not nil")
-		if !plan.SSLCert.Issuer.IsNull() && !plan.SSLCert.IsCA.IsNull() {
-			resp.Diagnostics.AddError(
-				"Validation error",
-				"IsCA should not be set if Issuer is set ",
-			)
-			return
-
-		}
-
-		err = r.createDefinition(plan.CollectionName.ValueString(), plan.DefinitionName.ValueString(), definition, plan.SSLCert)
+		err = createDefinition(ctx, r.client, plan.CollectionName.ValueString(), plan.DefinitionName.ValueString(), definition, plan.SSLCert)
 	}
 	if plan.SymetricKey != nil {
 		tflog.Info(ctx, "Symetric key is not nil")
-		err = r.createDefinition(plan.CollectionName.ValueString(), plan.DefinitionName.ValueString(), definition, plan.SymetricKey)
+		err = createDefinition(ctx, r.client, plan.CollectionName.ValueString(), plan.DefinitionName.ValueString(), definition, plan.SymetricKey)
+	}
+	if plan.AsymmetricKey != nil {
+		tflog.Info(ctx, "Asymmetric key is not nil")
+		err = createDefinition(ctx, r.client, plan.CollectionName.ValueString(), plan.DefinitionName.ValueString(), definition, plan.AsymmetricKey)
+	}
+	if plan.SSHKey != nil {
+		tflog.Info(ctx, "SSH key is not nil")
+		err = createDefinition(ctx, r.client, plan.CollectionName.ValueString(), plan.DefinitionName.ValueString(), definition, plan.SSHKey)
+	}
+	if plan.Password != nil {
+		tflog.Info(ctx, "Password is not nil")
+		err = createDefinition(ctx, r.client, plan.CollectionName.ValueString(), plan.DefinitionName.ValueString(), definition, plan.Password)
 	}
 	if plan.AzureSP != nil {
 		tflog.Info(ctx, "Azure SP is not nil")
-
-		if !regexp.MustCompile(`^[a-z0-9_\-]+$`).MatchString(plan.CollectionName.ValueString()) {
-			resp.Diagnostics.AddError(
-				"Validation error",
-				"Collection name should only contain lower case for Azure SP  ",
-			)
-			return
-		}
-		if !regexp.MustCompile(`^[a-z0-9_\-]+$`).MatchString(plan.DefinitionName.ValueString()) {
-			resp.Diagnostics.AddError(
-				"Validation error",
-				"DefinitionName should only contain lower case for Azure SP  ",
-			)
-			return
-		}
-		err = r.createDefinition(plan.CollectionName.ValueString(), plan.DefinitionName.ValueString(), definition, plan.AzureSP)
+		err = createDefinition(ctx, r.client, plan.CollectionName.ValueString(), plan.DefinitionName.ValueString(), definition, plan.AzureSP)
 	}
 
 	if !plan.Opaque.IsNull() {
 		tflog.Info(ctx, "Opaque is not nil")
-		err = r.createDefinition(plan.CollectionName.ValueString(), plan.DefinitionName.ValueString(), definition, Opaque{})
+		err = createDefinition(ctx, r.client, plan.CollectionName.ValueString(), plan.DefinitionName.ValueString(), definition, Opaque{})
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error creating Definition",
@@ -281,7 +771,7 @@ func (r *definitionsResource) Create(ctx context.Context, req resource.CreateReq
 		tflog.Info(ctx, "Transparent is not nil")
 		transparent := Transparent{}
 
-		err = r.createDefinition(plan.CollectionName.ValueString(), plan.DefinitionName.ValueString(), definition, transparent)
+		err = createDefinition(ctx, r.client, plan.CollectionName.ValueString(), plan.DefinitionName.ValueString(), definition, transparent)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error creating Definition",
@@ -316,8 +806,6 @@ func (r *definitionsResource) Create(ctx context.Context, req resource.CreateReq
 		)
 		return
 	}
-	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
-
 	definitionDetails, err := r.client.GetDefinition(plan.CollectionName.ValueString(), plan.DefinitionName.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -326,22 +814,10 @@ func (r *definitionsResource) Create(ctx context.Context, req resource.CreateReq
 		)
 		return
 	}
-	options := []DefinitionOption{}
-
-	for _, optionfromKmi := range definitionDetails.Option {
-
-		options = append(options, DefinitionOption{
-			Name:  types.StringValue(optionfromKmi.Name),
-			Value: types.StringValue(optionfromKmi.Text),
-		})
-	}
-
-	plan.Options = keySliceToList(ctx, options, &resp.Diagnostics)
-	var secretsIndex bytes.Buffer
-	for _, secret := range definitionDetails.Secret {
-		secretsIndex.WriteString(fmt.Sprintf("%s,", secret.Index))
+	plan.setDefinitionDetails(ctx, definitionDetails, &resp.Diagnostics)
+	if plan.SSLCert != nil {
+		plan.SSLCert.ReadSecret(ctx, r.client, plan.CollectionName.ValueString(), plan.DefinitionName.ValueString(), definitionDetails, &resp.Diagnostics)
 	}
-	plan.SecretIndexes = types.StringValue(secretsIndex.String())
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, plan)
@@ -369,21 +845,32 @@ func (r *definitionsResource) Read(ctx context.Context, req resource.ReadRequest
 		)
 		return
 	}
-	options := []DefinitionOption{}
+	imported := state.imported()
+	state.setDefinitionDetails(ctx, definitionDetails, &resp.Diagnostics)
 
-	for _, optionfromKmi := range definitionDetails.Option {
-
-		options = append(options, DefinitionOption{
-			Name:  types.StringValue(optionfromKmi.Name),
-			Value: types.StringValue(optionfromKmi.Text),
-		})
+	if imported {
+		state.setDefinitionType(definitionDetails.Type, &resp.Diagnostics)
 	}
-	state.Options = keySliceToList(ctx, options, &resp.Diagnostics)
-	var secretsIndex bytes.Buffer
-	for _, secret := range definitionDetails.Secret {
-		secretsIndex.WriteString(fmt.Sprintf("%s,", secret.Index))
+	state.setPermissions(definitionDetails)
+	if state.SSLCert != nil {
+		state.SSLCert.ReadResponse(definitionDetails, imported)
+		state.SSLCert.ReadSecret(ctx, r.client, state.CollectionName.ValueString(), state.DefinitionName.ValueString(), definitionDetails, &resp.Diagnostics)
+	}
+	if state.AzureSP != nil {
+		state.AzureSP.ReadResponse(definitionDetails, imported)
+	}
+	if state.SymetricKey != nil {
+		state.SymetricKey.ReadResponse(definitionDetails, imported)
+	}
+	if state.AsymmetricKey != nil {
+		state.AsymmetricKey.ReadResponse(definitionDetails, imported)
+	}
+	if state.SSHKey != nil {
+		state.SSHKey.ReadResponse(definitionDetails, imported)
+	}
+	if state.Password != nil {
+		state.Password.ReadResponse(definitionDetails, imported)
 	}
-	state.SecretIndexes = types.StringValue(secretsIndex.String())
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -393,6 +880,20 @@ func (r *definitionsResource) Read(ctx context.Context, req resource.ReadRequest
 
 }
 
+// ImportState imports a definition by its collection_name/name identifier.
+func (r *definitionsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	collectionName, definitionName, ok := strings.Cut(req.ID, "/")
+	if !ok || collectionName == "" || definitionName == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: collection_name/name. Got: %q", req.ID),
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("collection_name"), collectionName)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), definitionName)...)
+}
+
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *definitionsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	mu.Lock()
@@ -403,6 +904,12 @@ func (r *definitionsResource) Update(ctx context.Context, req resource.UpdateReq
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	var state definitionResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	definition := kmi.KMIDefinition{
 		Adders:    plan.Adders.ValueString(),
@@ -412,17 +919,26 @@ func (r *definitionsResource) Update(ctx context.Context, req resource.UpdateReq
 
 	var err error
 	if plan.SSLCert != nil {
-		err = r.createDefinition(plan.CollectionName.ValueString(), plan.DefinitionName.ValueString(), definition, plan.SSLCert)
+		err = createDefinition(ctx, r.client, plan.CollectionName.ValueString(), plan.DefinitionName.ValueString(), definition, plan.SSLCert)
 	}
 	if plan.SymetricKey != nil {
-		err = r.createDefinition(plan.CollectionName.ValueString(), plan.DefinitionName.ValueString(), definition, plan.SymetricKey)
+		err = createDefinition(ctx, r.client, plan.CollectionName.ValueString(), plan.DefinitionName.ValueString(), definition, plan.SymetricKey)
+	}
+	if plan.AsymmetricKey != nil {
+		err = createDefinition(ctx, r.client, plan.CollectionName.ValueString(), plan.DefinitionName.ValueString(), definition, plan.AsymmetricKey)
+	}
+	if plan.SSHKey != nil {
+		err = createDefinition(ctx, r.client, plan.CollectionName.ValueString(), plan.DefinitionName.ValueString(), definition, plan.SSHKey)
+	}
+	if plan.Password != nil {
+		err = createDefinition(ctx, r.client, plan.CollectionName.ValueString(), plan.DefinitionName.ValueString(), definition, plan.Password)
 	}
 	if plan.AzureSP != nil {
-		err = r.createDefinition(plan.CollectionName.ValueString(), plan.DefinitionName.ValueString(), definition, plan.AzureSP)
+		err = createDefinition(ctx, r.client, plan.CollectionName.ValueString(), plan.DefinitionName.ValueString(), definition, plan.AzureSP)
 	}
 
 	if !plan.Opaque.IsNull() {
-		err = r.createDefinition(plan.CollectionName.ValueString(), plan.DefinitionName.ValueString(), definition, Opaque{})
+		err = createDefinition(ctx, r.client, plan.CollectionName.ValueString(), plan.DefinitionName.ValueString(), definition, Opaque{})
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error creating Definition",
@@ -454,7 +970,7 @@ func (r *definitionsResource) Update(ctx context.Context, req resource.UpdateReq
 		tflog.Info(ctx, "Transparent is not nil")
 		transparent := Transparent{}
 
-		err = r.createDefinition(plan.CollectionName.ValueString(), plan.DefinitionName.ValueString(), definition, transparent)
+		err = createDefinition(ctx, r.client, plan.CollectionName.ValueString(), plan.DefinitionName.ValueString(), definition, transparent)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error creating Definition",
@@ -489,8 +1005,16 @@ func (r *definitionsResource) Update(ctx context.Context, req resource.UpdateReq
 		)
 		return
 	}
-	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
-
+	if state.generator() != nil && plan.generator() != nil {
+		err = deleteRemovedOptions(r.client, plan.CollectionName.ValueString(), plan.DefinitionName.ValueString(), state.generator(), plan.generator())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating Definition",
+				"Could not remove Definition options, unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
 	definitionDetails, err := r.client.GetDefinition(plan.CollectionName.ValueString(), plan.DefinitionName.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -499,22 +1023,10 @@ func (r *definitionsResource) Update(ctx context.Context, req resource.UpdateReq
 		)
 		return
 	}
-	options := []DefinitionOption{}
-
-	for _, optionfromKmi := range definitionDetails.Option {
-
-		options = append(options, DefinitionOption{
-			Name:  types.StringValue(optionfromKmi.Name),
-			Value: types.StringValue(optionfromKmi.Text),
-		})
-	}
-
-	plan.Options = keySliceToList(ctx, options, &resp.Diagnostics)
-	var secretsIndex bytes.Buffer
-	for _, secret := range definitionDetails.Secret {
-		secretsIndex.WriteString(fmt.Sprintf("%s,", secret.Index))
+	plan.setDefinitionDetails(ctx, definitionDetails, &resp.Diagnostics)
+	if plan.SSLCert != nil {
+		plan.SSLCert.ReadSecret(ctx, r.client, plan.CollectionName.ValueString(), plan.DefinitionName.ValueString(), definitionDetails, &resp.Diagnostics)
 	}
-	plan.SecretIndexes = types.StringValue(secretsIndex.String())
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, plan)
@@ -575,51 +1087,161 @@ func boolStr(s bool) string {
 }
 
 type definitionResourceModel struct {
+	definitionCommonModel
+	SSLCert       *SSLCert       `tfsdk:"ssl_cert"`
+	AzureSP       *AzureSP       `tfsdk:"azure_sp"`
+	Opaque        types.String   `tfsdk:"opaque"`
+	B64Encoded    types.Bool     `tfsdk:"b64encoded"`
+	Transparent   types.String   `tfsdk:"transparent"`
+	SymetricKey   *SymetricKey   `tfsdk:"symmetric_key"`
+	AsymmetricKey *AsymmetricKey `tfsdk:"asymmetric_key"`
+	SSHKey        *SSHKey        `tfsdk:"ssh_key"`
+	Password      *Password      `tfsdk:"password"`
+}
+
+// definitionCommonModel holds the attributes shared by every definition resource.
+type definitionCommonModel struct {
 	Adders         types.String `tfsdk:"adders"`
 	Modifiers      types.String `tfsdk:"modifiers"`
 	Readers        types.String `tfsdk:"readers"`
 	DefinitionName types.String `tfsdk:"name"`
 	CollectionName types.String `tfsdk:"collection_name"`
 	LastUpdated    types.String `tfsdk:"last_updated"`
-	SSLCert        *SSLCert     `tfsdk:"ssl_cert"`
-	AzureSP        *AzureSP     `tfsdk:"azure_sp"`
-	Opaque         types.String `tfsdk:"opaque"`
-	B64Encoded     types.Bool   `tfsdk:"b64encoded"`
-	Transparent    types.String `tfsdk:"transparent"`
-	SymetricKey    *SymetricKey `tfsdk:"symmetric_key"`
-	Options        types.List   `tfsdk:"options"`
-	SecretIndexes  types.String `tfsdk:"secret_indexes"`
+	Options        types.Map    `tfsdk:"options"`
+	SecretIndexes  types.List   `tfsdk:"secret_indexes"`
+}
+
+// setDefinitionType sets up the block of the definition type KMI reports, for
+// state which has no definition type yet.
+func (m *definitionResourceModel) setDefinitionType(definitionType string, diags *diag.Diagnostics) {
+	switch definitionType {
+	case "ssl_cert":
+		m.SSLCert = &SSLCert{}
+	case "azure_sp":
+		m.AzureSP = &AzureSP{}
+	case "symmetric_key":
+		m.SymetricKey = &SymetricKey{}
+	case "asymmetric_key":
+		m.AsymmetricKey = &AsymmetricKey{}
+	case "ssh_key":
+		m.SSHKey = &SSHKey{}
+	case "password":
+		m.Password = &Password{}
+	case "opaque", "transparent":
+		diags.AddWarning(
+			"Incomplete Definition Import",
+			fmt.Sprintf("The content of %s definitions cannot be read from KMI. Once %s is set in the configuration, the next apply replaces the definition.", definitionType, definitionType),
+		)
+	default:
+		diags.AddError(
+			"Unsupported Definition Type",
+			fmt.Sprintf("Could not import definition of type %q.", definitionType),
+		)
+	}
 }
 
-type DefinitionOption struct {
-	Name  types.String `tfsdk:"name"`
-	Value types.String `tfsdk:"value"`
+// generator returns the configured definition type, or nil when none is configured.
+func (m definitionResourceModel) generator() kmigenerator {
+	switch {
+	case m.SSLCert != nil:
+		return m.SSLCert
+	case m.AzureSP != nil:
+		return m.AzureSP
+	case m.SymetricKey != nil:
+		return m.SymetricKey
+	case m.AsymmetricKey != nil:
+		return m.AsymmetricKey
+	case m.SSHKey != nil:
+		return m.SSHKey
+	case m.Password != nil:
+		return m.Password
+	case !m.Opaque.IsNull():
+		return Opaque{}
+	case !m.Transparent.IsNull():
+		return Transparent{}
+	}
+	return nil
 }
 
-func (o DefinitionOption) attrTypes() map[string]attr.Type {
+// DefinitionSecret maps a secret stored under the definition.
+type DefinitionSecret struct {
+	Index      types.String `tfsdk:"index"`
+	AddDate    types.String `tfsdk:"add_date"`
+	ExpireDate types.String `tfsdk:"expire_date"`
+	Status     types.String `tfsdk:"status"`
+}
+
+func (o DefinitionSecret) attrTypes() map[string]attr.Type {
 	return map[string]attr.Type{
-		"name":  types.StringType,
-		"value": types.StringType,
+		"index":       types.StringType,
+		"add_date":    types.StringType,
+		"expire_date": types.StringType,
+		"status":      types.StringType,
 	}
 }
 
-func keySliceToList(ctx context.Context, keysSliceIn []DefinitionOption, diags *diag.Diagnostics) types.List {
-	keys, d := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: DefinitionOption{}.attrTypes()}, keysSliceIn)
+// setDefinitionDetails maps the computed attributes returned by KMI onto the model.
+func (m *definitionCommonModel) setDefinitionDetails(ctx context.Context, definitionDetails *kmi.KMIDefinitionResponse, diags *diag.Diagnostics) {
+	m.LastUpdated = types.StringValue(definitionDetails.Modified)
+
+	options := map[string]string{}
+	for _, optionfromKmi := range definitionDetails.Option {
+		options[optionfromKmi.Name] = optionfromKmi.Text
+	}
+	optionsMap, d := types.MapValueFrom(ctx, types.StringType, options)
+	diags.Append(d...)
+	m.Options = optionsMap
+
+	secrets := []DefinitionSecret{}
+	for _, secret := range definitionDetails.Secret {
+		secrets = append(secrets, DefinitionSecret{
+			Index:      types.StringValue(secret.Index),
+			AddDate:    types.StringValue(secret.AddDate),
+			ExpireDate: types.StringValue(secret.ExpireDate),
+			Status:     types.StringValue(secret.Status),
+		})
+	}
+	secretIndexes, d := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: DefinitionSecret{}.attrTypes()}, secrets)
 	diags.Append(d...)
-	return keys
+	m.SecretIndexes = secretIndexes
+}
+
+// setPermissions refreshes the permission groups of the definition. KMI omits the
+// groups which are not set.
+func (m *definitionCommonModel) setPermissions(definitionDetails *kmi.KMIDefinitionResponse) {
+	m.Adders = optionalString(definitionDetails.Adders)
+	m.Modifiers = optionalString(definitionDetails.Modifiers)
+	m.Readers = optionalString(definitionDetails.Readers)
 }
 
 type kmigenerator interface {
 	RequestPayload(kmi.KMIDefinition) (kmi.KMIDefinition, error)
 }
 
-func (r *definitionsResource) createDefinition(collectionName string, definitionName string, definition kmi.KMIDefinition, kmigenerator kmigenerator) error {
+// imported reports whether the state was just imported. Only ImportState leaves
+// last_updated unset, every other operation stores the KMI modified timestamp.
+func (m *definitionCommonModel) imported() bool {
+	return m.LastUpdated.IsNull()
+}
+
+// kmireader is implemented by the definition types which refresh their
+// attributes from the definition returned by KMI.
+type kmireader interface {
+	ReadResponse(definitionDetails *kmi.KMIDefinitionResponse, imported bool)
+}
+
+// createDefinition sends the payload of kmigenerator to KMI.
+func createDefinition(ctx context.Context, client *kmi.KMIRestClient, collectionName string, definitionName string, definition kmi.KMIDefinition, kmigenerator kmigenerator) error {
 	out, err := kmigenerator.RequestPayload(definition)
-	fmt.Printf("CreateDefinition payload %v\n", out)
 	if err != nil {
 		return err
 	}
-	return r.client.CreateDefinition(collectionName, definitionName, out)
+	tflog.Debug(ctx, "Creating KMI definition", map[string]interface{}{
+		"collection_name": collectionName,
+		"name":            definitionName,
+		"type":            out.Type,
+	})
+	return client.CreateDefinition(collectionName, definitionName, out)
 }
 
 type Opaque struct {
@@ -646,24 +1268,34 @@ type SSLCert struct {
 	IsCA            types.Int64  `tfsdk:"is_ca"`
 	Subject         types.String `tfsdk:"subject"`
 	Cn              types.String `tfsdk:"cn"`
-	SubjectAltNames types.String `tfsdk:"subj_alt_names"`
-	SubjectAltUris  types.String `tfsdk:"subj_alt_uris"`
+	SubjectAltNames types.Set    `tfsdk:"subj_alt_names"`
+	SubjectAltUris  types.Set    `tfsdk:"subj_alt_uris"`
 	CAName          types.String `tfsdk:"ca_name"`
-	SignACL         types.String `tfsdk:"signacl"`
-	SignACLDomain   types.String `tfsdk:"signacldomain"`
-	SignACLGroup    types.String `tfsdk:"signaclgroup"`
+	SignACL         types.Set    `tfsdk:"signacl"`
+	SignACLDomain   types.Set    `tfsdk:"signacldomain"`
+	SignACLGroup    types.Set    `tfsdk:"signaclgroup"`
+	ExtraOptions    types.Map    `tfsdk:"extra_options"`
+	ExpiryWarning   types.String `tfsdk:"expiry_warning_window"`
+	ErrorIfExpired  types.Bool   `tfsdk:"error_if_expired"`
+	Certificate     types.Object `tfsdk:"certificate"`
 }
 
-func (s SSLCert) RequestPayload(definition kmi.KMIDefinition) (kmi.KMIDefinition, error) {
-
-	if !s.Issuer.IsNull() && !s.IsCA.IsNull() {
-		return kmi.KMIDefinition{}, fmt.Errorf("IsCA should not be set if Issuer is set ")
-	}
-
-	if !s.Subject.IsNull() && !s.Cn.IsNull() {
-		return kmi.KMIDefinition{}, fmt.Errorf("Subject should not be set if CN is set ")
-	}
+var (
+	// sslCertOptionNames are the ssl_cert options managed by dedicated attributes.
+	sslCertOptionNames = []string{"is_ca", "issuer", "subject", "cn", "subj_alt_names", "subj_alt_uris", "ca_name"}
+	// sslCertOptionPrefixes are the prefixes of the ssl_cert ACL options.
+	sslCertOptionPrefixes = []string{"signacl:", "signacldomain:", "signaclgroup:"}
+	// symmetricKeyOptionNames are the symmetric_key options managed by dedicated attributes.
+	symmetricKeyOptionNames = []string{"key_size_bytes"}
+	// asymmetricKeyOptionNames are the asymmetric_key options managed by dedicated attributes.
+	asymmetricKeyOptionNames = []string{"key_type", "key_size", "curve"}
+	// sshKeyOptionNames are the ssh_key options managed by dedicated attributes.
+	sshKeyOptionNames = []string{"key_type", "key_size"}
+	// passwordOptionNames are the password options managed by dedicated attributes.
+	passwordOptionNames = []string{"length", "charset"}
+)
 
+func (s SSLCert) RequestPayload(definition kmi.KMIDefinition) (kmi.KMIDefinition, error) {
 	var options []*kmi.KMIOption
 	if !s.IsCA.IsNull() {
 		option := &kmi.KMIOption{
@@ -696,38 +1328,20 @@ func (s SSLCert) RequestPayload(definition kmi.KMIDefinition) (kmi.KMIDefinition
 	if !s.SubjectAltNames.IsNull() {
 		option := &kmi.KMIOption{
 			Name: "subj_alt_names",
-			Text: s.SubjectAltNames.ValueString(),
+			Text: joinSet(s.SubjectAltNames),
 		}
 		options = append(options, option)
 	}
 	if !s.SubjectAltUris.IsNull() {
 		option := &kmi.KMIOption{
 			Name: "subj_alt_uris",
-			Text: s.SubjectAltUris.ValueString(),
-		}
-		options = append(options, option)
-	}
-	if !s.SignACL.IsNull() {
-		option := &kmi.KMIOption{
-			Name: "signacl:" + s.SignACL.ValueString(),
-			Text: "true",
-		}
-		options = append(options, option)
-	}
-	if !s.SignACLDomain.IsNull() {
-		option := &kmi.KMIOption{
-			Name: "signacldomain:" + s.SignACLDomain.ValueString(),
-			Text: "true",
-		}
-		options = append(options, option)
-	}
-	if !s.SignACLGroup.IsNull() {
-		option := &kmi.KMIOption{
-			Name: "signaclgroup:" + s.SignACLGroup.ValueString(),
-			Text: "true",
+			Text: joinSet(s.SubjectAltUris),
 		}
 		options = append(options, option)
 	}
+	options = append(options, aclKMIOptions("signacl:", s.SignACL)...)
+	options = append(options, aclKMIOptions("signacldomain:", s.SignACLDomain)...)
+	options = append(options, aclKMIOptions("signaclgroup:", s.SignACLGroup)...)
 	if !s.CAName.IsNull() {
 		option := &kmi.KMIOption{
 			Name: "ca_name",
@@ -736,6 +1350,8 @@ func (s SSLCert) RequestPayload(definition kmi.KMIDefinition) (kmi.KMIDefinition
 		options = append(options, option)
 	}
 
+	options = append(options, extraKMIOptions(s.ExtraOptions)...)
+
 	definition.AutoGenerate = boolStr(s.AutoGenerate.ValueBool())
 	definition.Type = "ssl_cert"
 	definition.ExpirePeriod = s.ExpiryPeriod.ValueString()
@@ -744,21 +1360,47 @@ func (s SSLCert) RequestPayload(definition kmi.KMIDefinition) (kmi.KMIDefinition
 	return definition, nil
 }
 
+func (s *SSLCert) ReadResponse(definitionDetails *kmi.KMIDefinitionResponse, imported bool) {
+	options := definitionOptions(definitionDetails)
+	s.AutoGenerate = types.BoolValue(strings.EqualFold(definitionDetails.AutoGenerate, "true"))
+	s.ExpiryPeriod = configuredValue(imported, s.ExpiryPeriod, optionalString(definitionDetails.ExpirePeriod))
+	s.RefreshPeriod = configuredValue(imported, s.RefreshPeriod, optionalString(definitionDetails.RefreshPeriod))
+	s.IsCA = configuredValue(imported, s.IsCA, optionInt64(options, "is_ca"))
+	s.Issuer = configuredValue(imported, s.Issuer, optionString(options, "issuer"))
+	s.Subject = configuredValue(imported, s.Subject, optionString(options, "subject"))
+	s.Cn = configuredValue(imported, s.Cn, optionString(options, "cn"))
+	s.SubjectAltNames = configuredValue(imported, s.SubjectAltNames, optionSet(options, "subj_alt_names"))
+	s.SubjectAltUris = configuredValue(imported, s.SubjectAltUris, optionSet(options, "subj_alt_uris"))
+	s.CAName = configuredValue(imported, s.CAName, optionString(options, "ca_name"))
+	s.SignACL = aclOptions(options, "signacl:")
+	s.SignACLDomain = aclOptions(options, "signacldomain:")
+	s.SignACLGroup = aclOptions(options, "signaclgroup:")
+	s.ExtraOptions = readExtraOptions(imported, s.ExtraOptions, options, sslCertOptionNames, sslCertOptionPrefixes)
+}
+
 type AzureSP struct {
 	AutoGenerate types.Bool `tfsdk:"auto_generate"`
+	ExtraOptions types.Map  `tfsdk:"extra_options"`
 }
 
 func (sp AzureSP) RequestPayload(definition kmi.KMIDefinition) (kmi.KMIDefinition, error) {
 	definition.AutoGenerate = boolStr(sp.AutoGenerate.ValueBool())
 	definition.Type = "azure_sp"
+	definition.Options = extraKMIOptions(sp.ExtraOptions)
 	return definition, nil
 }
 
+func (sp *AzureSP) ReadResponse(definitionDetails *kmi.KMIDefinitionResponse, imported bool) {
+	sp.AutoGenerate = types.BoolValue(strings.EqualFold(definitionDetails.AutoGenerate, "true"))
+	sp.ExtraOptions = readExtraOptions(imported, sp.ExtraOptions, definitionOptions(definitionDetails), nil, nil)
+}
+
 type SymetricKey struct {
 	AutoGenerate  types.Bool   `tfsdk:"auto_generate"`
 	ExpiryPeriod  types.String `tfsdk:"expire_period"`
 	RefreshPeriod types.String `tfsdk:"refresh_period"`
 	KeySizeBytes  types.Int64  `tfsdk:"key_size_bytes"`
+	ExtraOptions  types.Map    `tfsdk:"extra_options"`
 }
 
 func (sk SymetricKey) RequestPayload(definition kmi.KMIDefinition) (kmi.KMIDefinition, error) {
@@ -771,6 +1413,8 @@ func (sk SymetricKey) RequestPayload(definition kmi.KMIDefinition) (kmi.KMIDefin
 		options = append(options, option)
 	}
 
+	options = append(options, extraKMIOptions(sk.ExtraOptions)...)
+
 	definition.AutoGenerate = boolStr(sk.AutoGenerate.ValueBool())
 	definition.Type = "symmetric_key"
 	definition.ExpirePeriod = sk.ExpiryPeriod.ValueString()
@@ -778,3 +1422,344 @@ func (sk SymetricKey) RequestPayload(definition kmi.KMIDefinition) (kmi.KMIDefin
 	definition.Options = options
 	return definition, nil
 }
+
+func (sk *SymetricKey) ReadResponse(definitionDetails *kmi.KMIDefinitionResponse, imported bool) {
+	options := definitionOptions(definitionDetails)
+	sk.AutoGenerate = types.BoolValue(strings.EqualFold(definitionDetails.AutoGenerate, "true"))
+	sk.ExpiryPeriod = configuredValue(imported, sk.ExpiryPeriod, optionalString(definitionDetails.ExpirePeriod))
+	sk.RefreshPeriod = configuredValue(imported, sk.RefreshPeriod, optionalString(definitionDetails.RefreshPeriod))
+	sk.KeySizeBytes = configuredValue(imported, sk.KeySizeBytes, optionInt64(options, "key_size_bytes"))
+	sk.ExtraOptions = readExtraOptions(imported, sk.ExtraOptions, options, symmetricKeyOptionNames, nil)
+}
+
+type AsymmetricKey struct {
+	AutoGenerate  types.Bool   `tfsdk:"auto_generate"`
+	ExpiryPeriod  types.String `tfsdk:"expire_period"`
+	RefreshPeriod types.String `tfsdk:"refresh_period"`
+	KeyType       types.String `tfsdk:"key_type"`
+	KeySize       types.Int64  `tfsdk:"key_size"`
+	Curve         types.String `tfsdk:"curve"`
+	ExtraOptions  types.Map    `tfsdk:"extra_options"`
+}
+
+func (ak AsymmetricKey) RequestPayload(definition kmi.KMIDefinition) (kmi.KMIDefinition, error) {
+	options := []*kmi.KMIOption{{
+		Name: "key_type",
+		Text: ak.KeyType.ValueString(),
+	}}
+	if !ak.KeySize.IsNull() {
+		options = append(options, &kmi.KMIOption{
+			Name: "key_size",
+			Text: fmt.Sprintf("%d", ak.KeySize.ValueInt64()),
+		})
+	}
+	if !ak.Curve.IsNull() {
+		options = append(options, &kmi.KMIOption{
+			Name: "curve",
+			Text: ak.Curve.ValueString(),
+		})
+	}
+	options = append(options, extraKMIOptions(ak.ExtraOptions)...)
+
+	definition.AutoGenerate = boolStr(ak.AutoGenerate.ValueBool())
+	definition.Type = "asymmetric_key"
+	definition.ExpirePeriod = ak.ExpiryPeriod.ValueString()
+	definition.RefreshPeriod = ak.RefreshPeriod.ValueString()
+	definition.Options = options
+	return definition, nil
+}
+
+func (ak *AsymmetricKey) ReadResponse(definitionDetails *kmi.KMIDefinitionResponse, imported bool) {
+	options := definitionOptions(definitionDetails)
+	ak.AutoGenerate = types.BoolValue(strings.EqualFold(definitionDetails.AutoGenerate, "true"))
+	ak.ExpiryPeriod = configuredValue(imported, ak.ExpiryPeriod, optionalString(definitionDetails.ExpirePeriod))
+	ak.RefreshPeriod = configuredValue(imported, ak.RefreshPeriod, optionalString(definitionDetails.RefreshPeriod))
+	ak.KeyType = optionString(options, "key_type")
+	ak.KeySize = configuredValue(imported, ak.KeySize, optionInt64(options, "key_size"))
+	ak.Curve = configuredValue(imported, ak.Curve, optionString(options, "curve"))
+	ak.ExtraOptions = readExtraOptions(imported, ak.ExtraOptions, options, asymmetricKeyOptionNames, nil)
+}
+
+type SSHKey struct {
+	AutoGenerate  types.Bool   `tfsdk:"auto_generate"`
+	ExpiryPeriod  types.String `tfsdk:"expire_period"`
+	RefreshPeriod types.String `tfsdk:"refresh_period"`
+	KeyType       types.String `tfsdk:"key_type"`
+	KeySize       types.Int64  `tfsdk:"key_size"`
+	ExtraOptions  types.Map    `tfsdk:"extra_options"`
+}
+
+func (sk SSHKey) RequestPayload(definition kmi.KMIDefinition) (kmi.KMIDefinition, error) {
+	options := []*kmi.KMIOption{{
+		Name: "key_type",
+		Text: sk.KeyType.ValueString(),
+	}}
+	if !sk.KeySize.IsNull() {
+		options = append(options, &kmi.KMIOption{
+			Name: "key_size",
+			Text: fmt.Sprintf("%d", sk.KeySize.ValueInt64()),
+		})
+	}
+	options = append(options, extraKMIOptions(sk.ExtraOptions)...)
+
+	definition.AutoGenerate = boolStr(sk.AutoGenerate.ValueBool())
+	definition.Type = "ssh_key"
+	definition.ExpirePeriod = sk.ExpiryPeriod.ValueString()
+	definition.RefreshPeriod = sk.RefreshPeriod.ValueString()
+	definition.Options = options
+	return definition, nil
+}
+
+func (sk *SSHKey) ReadResponse(definitionDetails *kmi.KMIDefinitionResponse, imported bool) {
+	options := definitionOptions(definitionDetails)
+	sk.AutoGenerate = types.BoolValue(strings.EqualFold(definitionDetails.AutoGenerate, "true"))
+	sk.ExpiryPeriod = configuredValue(imported, sk.ExpiryPeriod, optionalString(definitionDetails.ExpirePeriod))
+	sk.RefreshPeriod = configuredValue(imported, sk.RefreshPeriod, optionalString(definitionDetails.RefreshPeriod))
+	sk.KeyType = optionString(options, "key_type")
+	sk.KeySize = configuredValue(imported, sk.KeySize, optionInt64(options, "key_size"))
+	sk.ExtraOptions = readExtraOptions(imported, sk.ExtraOptions, options, sshKeyOptionNames, nil)
+}
+
+type Password struct {
+	AutoGenerate  types.Bool   `tfsdk:"auto_generate"`
+	ExpiryPeriod  types.String `tfsdk:"expire_period"`
+	RefreshPeriod types.String `tfsdk:"refresh_period"`
+	Length        types.Int64  `tfsdk:"length"`
+	Charset       types.String `tfsdk:"charset"`
+	ExtraOptions  types.Map    `tfsdk:"extra_options"`
+}
+
+func (pw Password) RequestPayload(definition kmi.KMIDefinition) (kmi.KMIDefinition, error) {
+	var options []*kmi.KMIOption
+	if !pw.Length.IsNull() {
+		options = append(options, &kmi.KMIOption{
+			Name: "length",
+			Text: fmt.Sprintf("%d", pw.Length.ValueInt64()),
+		})
+	}
+	if !pw.Charset.IsNull() {
+		options = append(options, &kmi.KMIOption{
+			Name: "charset",
+			Text: pw.Charset.ValueString(),
+		})
+	}
+	options = append(options, extraKMIOptions(pw.ExtraOptions)...)
+
+	definition.AutoGenerate = boolStr(pw.AutoGenerate.ValueBool())
+	definition.Type = "password"
+	definition.ExpirePeriod = pw.ExpiryPeriod.ValueString()
+	definition.RefreshPeriod = pw.RefreshPeriod.ValueString()
+	definition.Options = options
+	return definition, nil
+}
+
+func (pw *Password) ReadResponse(definitionDetails *kmi.KMIDefinitionResponse, imported bool) {
+	options := definitionOptions(definitionDetails)
+	pw.AutoGenerate = types.BoolValue(strings.EqualFold(definitionDetails.AutoGenerate, "true"))
+	pw.ExpiryPeriod = configuredValue(imported, pw.ExpiryPeriod, optionalString(definitionDetails.ExpirePeriod))
+	pw.RefreshPeriod = configuredValue(imported, pw.RefreshPeriod, optionalString(definitionDetails.RefreshPeriod))
+	pw.Length = configuredValue(imported, pw.Length, optionInt64(options, "length"))
+	pw.Charset = configuredValue(imported, pw.Charset, optionString(options, "charset"))
+	pw.ExtraOptions = readExtraOptions(imported, pw.ExtraOptions, options, passwordOptionNames, nil)
+}
+
+// definitionOptions indexes the options of a KMI definition by name.
+func definitionOptions(definitionDetails *kmi.KMIDefinitionResponse) map[string]string {
+	options := map[string]string{}
+	for _, option := range definitionDetails.Option {
+		options[option.Name] = option.Text
+	}
+	return options
+}
+
+// configuredValue returns the value KMI reports for an attribute configured in
+// prior. Unconfigured attributes stay null, so the defaults KMI fills in do not
+// show up as a diff against the configuration. An import has no configuration
+// yet and takes every value from KMI.
+func configuredValue[T attr.Value](imported bool, prior T, value T) T {
+	if !imported && prior.IsNull() {
+		return prior
+	}
+	return value
+}
+
+// optionalString maps an empty KMI value to null.
+func optionalString(value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
+}
+
+// optionString returns the named option, or null when KMI did not return it.
+func optionString(options map[string]string, name string) types.String {
+	value, ok := options[name]
+	if !ok {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
+}
+
+// optionSet returns the named comma delimited option as a set, or null when KMI
+// did not return it.
+func optionSet(options map[string]string, name string) types.Set {
+	value, ok := options[name]
+	if !ok {
+		return types.SetNull(types.StringType)
+	}
+	return splitToSet(value)
+}
+
+// optionInt64 returns the named numeric option, or null when KMI did not return
+// it or it is not a number.
+func optionInt64(options map[string]string, name string) types.Int64 {
+	value, err := strconv.ParseInt(options[name], 10, 64)
+	if err != nil {
+		return types.Int64Null()
+	}
+	return types.Int64Value(value)
+}
+
+// aclOptions returns the names of the ACL options with the given prefix, such as
+// signacl:<collection>, or null when there are none.
+func aclOptions(options map[string]string, prefix string) types.Set {
+	var names []attr.Value
+	for name := range options {
+		if strings.HasPrefix(name, prefix) {
+			names = append(names, types.StringValue(strings.TrimPrefix(name, prefix)))
+		}
+	}
+	if len(names) == 0 {
+		return types.SetNull(types.StringType)
+	}
+	return types.SetValueMust(types.StringType, names)
+}
+
+// aclKMIOptions converts a set of ACL names into KMI options with the given prefix,
+// ordered by name.
+func aclKMIOptions(prefix string, acl types.Set) []*kmi.KMIOption {
+	var options []*kmi.KMIOption
+	for _, name := range setStrings(acl) {
+		options = append(options, &kmi.KMIOption{
+			Name: prefix + name,
+			Text: "true",
+		})
+	}
+	return options
+}
+
+// removedOptions returns the names of the options sent to KMI for prior which are
+// no longer sent for plan. KMI keeps options that are left out of an update, so
+// these have to be deleted explicitly.
+func removedOptions(prior kmigenerator, plan kmigenerator) ([]string, error) {
+	priorDefinition, err := prior.RequestPayload(kmi.KMIDefinition{})
+	if err != nil {
+		return nil, err
+	}
+	planDefinition, err := plan.RequestPayload(kmi.KMIDefinition{})
+	if err != nil {
+		return nil, err
+	}
+
+	planned := map[string]bool{}
+	for _, option := range planDefinition.Options {
+		planned[option.Name] = true
+	}
+	var removed []string
+	for _, option := range priorDefinition.Options {
+		if !planned[option.Name] {
+			removed = append(removed, option.Name)
+		}
+	}
+	return removed, nil
+}
+
+// deleteRemovedOptions deletes the options of prior which are no longer part of plan.
+func deleteRemovedOptions(client *kmi.KMIRestClient, collectionName string, definitionName string, prior kmigenerator, plan kmigenerator) error {
+	removed, err := removedOptions(prior, plan)
+	if err != nil {
+		return err
+	}
+	for _, name := range removed {
+		err = client.DeleteDefinitionOption(collectionName, definitionName, name)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// extraKMIOptions converts an extra_options map into KMI options, ordered by name.
+func extraKMIOptions(extraOptions types.Map) []*kmi.KMIOption {
+	elements := extraOptions.Elements()
+	names := make([]string, 0, len(elements))
+	for name := range elements {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var options []*kmi.KMIOption
+	for _, name := range names {
+		value, ok := elements[name].(types.String)
+		if !ok || value.IsNull() || value.IsUnknown() {
+			continue
+		}
+		options = append(options, &kmi.KMIOption{
+			Name: name,
+			Text: value.ValueString(),
+		})
+	}
+	return options
+}
+
+// readExtraOptions refreshes extra_options. An import takes every option KMI
+// returns which is not managed by a dedicated attribute.
+func readExtraOptions(imported bool, extraOptions types.Map, kmiOptions map[string]string, names []string, prefixes []string) types.Map {
+	if !imported {
+		return reconcileExtraOptions(extraOptions, kmiOptions)
+	}
+
+	unmanaged := map[string]attr.Value{}
+	for name, value := range kmiOptions {
+		if !isManagedOption(name, names, prefixes) {
+			unmanaged[name] = types.StringValue(value)
+		}
+	}
+	if len(unmanaged) == 0 {
+		return types.MapNull(types.StringType)
+	}
+	return types.MapValueMust(types.StringType, unmanaged)
+}
+
+// isManagedOption reports whether the option is one of names or starts with one
+// of prefixes.
+func isManagedOption(name string, names []string, prefixes []string) bool {
+	for _, managed := range names {
+		if name == managed {
+			return true
+		}
+	}
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// reconcileExtraOptions refreshes the user managed extra_options from the options
+// returned by KMI. Options KMI defaulted on its own are left out, and managed
+// options that no longer exist are dropped so the drift shows up in the plan.
+func reconcileExtraOptions(extraOptions types.Map, kmiOptions map[string]string) types.Map {
+	if extraOptions.IsNull() || extraOptions.IsUnknown() {
+		return extraOptions
+	}
+
+	reconciled := map[string]attr.Value{}
+	for name := range extraOptions.Elements() {
+		if value, ok := kmiOptions[name]; ok {
+			reconciled[name] = types.StringValue(value)
+		}
+	}
+	return types.MapValueMust(types.StringType, reconciled)
+}
@@ -1,11 +1,19 @@
 package provider
 
 import (
+	"context"
+	"encoding/json"
 	"encoding/xml"
 	"fmt"
 	"reflect"
 	"terraform-provider-kmi/internal/kmi"
 	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 )
 
 func Test_Definition_AzureDirectly(t *testing.T) {
@@ -116,3 +124,472 @@ func Test_Definition_SSL_subject(t *testing.T) {
 		t.Errorf("Marshalling() = %v, want %v", out, data)
 	}
 }
+
+func Test_DefinitionDetails(t *testing.T) {
+	data := []byte(`<definition name="pim_ssl_definition" source="restserv:user:hachandr_kmi_cert" type="ssl_cert" modified="355396416">
+	<auto_generate>True</auto_generate>
+	<option name="cn" source="restserv:user:hachandr_kmi_cert">test-user</option>
+	<option name="key_size" source="restserv:user:hachandr_kmi_cert">2048</option>
+	<secret index="355396416" add_date="2024-01-14 04:19:42" expire_date="2024-04-14 04:19:42" status="active"/>
+	<secret index="355396500" add_date="2024-02-14 04:19:42" expire_date="2024-05-14 04:19:42" status="pending"/>
+  </definition>`)
+	var details kmi.KMIDefinitionResponse
+	err := xml.Unmarshal(data, &details)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	var diags diag.Diagnostics
+	model := definitionResourceModel{}
+	model.setDefinitionDetails(ctx, &details, &diags)
+	if diags.HasError() {
+		t.Fatalf("setDefinitionDetails() diagnostics = %v", diags)
+	}
+
+	if !reflect.DeepEqual(model.LastUpdated.ValueString(), "355396416") {
+		t.Errorf("LastUpdated = %v, want %v", model.LastUpdated, "355396416")
+	}
+
+	options := map[string]string{}
+	diags.Append(model.Options.ElementsAs(ctx, &options, false)...)
+	if !reflect.DeepEqual(options, map[string]string{"cn": "test-user", "key_size": "2048"}) {
+		t.Errorf("Options = %v", options)
+	}
+
+	var secrets []DefinitionSecret
+	diags.Append(model.SecretIndexes.ElementsAs(ctx, &secrets, false)...)
+	if diags.HasError() {
+		t.Fatalf("ElementsAs() diagnostics = %v", diags)
+	}
+	if len(secrets) != 2 {
+		t.Fatalf("SecretIndexes length = %d, want 2", len(secrets))
+	}
+	if !reflect.DeepEqual(secrets[0].Status.ValueString(), "active") {
+		t.Errorf("SecretIndexes[0].Status = %v, want %v", secrets[0].Status, "active")
+	}
+	if !reflect.DeepEqual(secrets[1].ExpireDate.ValueString(), "2024-05-14 04:19:42") {
+		t.Errorf("SecretIndexes[1].ExpireDate = %v, want %v", secrets[1].ExpireDate, "2024-05-14 04:19:42")
+	}
+}
+
+func Test_DefinitionUpgradeStateV0(t *testing.T) {
+	ctx := context.Background()
+	r, ok := NewDefinitionsResource().(fwresource.ResourceWithUpgradeState)
+	if !ok {
+		t.Fatal("definitions resource does not implement ResourceWithUpgradeState")
+	}
+
+	req := fwresource.UpgradeStateRequest{
+		RawState: &tfprotov6.RawState{
+			JSON: []byte(`{"name":"def","collection_name":"col","options":[{"name":"cn","value":"test"}],"secret_indexes":"1,2,"}`),
+		},
+	}
+	resp := &fwresource.UpgradeStateResponse{}
+	r.UpgradeState(ctx)[0].StateUpgrader(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("UpgradeState() diagnostics = %v", resp.Diagnostics)
+	}
+
+	var upgraded map[string]any
+	if err := json.Unmarshal(resp.DynamicValue.JSON, &upgraded); err != nil {
+		t.Fatal(err)
+	}
+	if upgraded["options"] != nil || upgraded["secret_indexes"] != nil {
+		t.Errorf("UpgradeState() = %v, want options and secret_indexes cleared", upgraded)
+	}
+	if !reflect.DeepEqual(upgraded["name"], "def") {
+		t.Errorf("UpgradeState() name = %v, want %v", upgraded["name"], "def")
+	}
+}
+
+func Test_Definition_SymetricKeyExtraOptions(t *testing.T) {
+	sk := SymetricKey{
+		AutoGenerate:  types.BoolValue(true),
+		ExpiryPeriod:  types.StringValue("3 months"),
+		RefreshPeriod: types.StringValue("1 months"),
+		KeySizeBytes:  types.Int64Value(16),
+		ExtraOptions: types.MapValueMust(types.StringType, map[string]attr.Value{
+			"key_usage": types.StringValue("encrypt"),
+			"algorithm": types.StringValue("aes"),
+		}),
+	}
+	defn, err := sk.RequestPayload(kmi.KMIDefinition{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, _ := xml.MarshalIndent(defn, "", "")
+
+	data := []byte(`<definition type="symmetric_key"><expire_period>3 months</expire_period><refresh_period>1 months</refresh_period><auto_generate>True</auto_generate><option name="key_size_bytes">16</option><option name="algorithm">aes</option><option name="key_usage">encrypt</option></definition>`)
+	if !reflect.DeepEqual(string(out), string(data)) {
+		t.Errorf("Marshalling() = %v, want %v", string(out), string(data))
+	}
+}
+
+func Test_ReconcileExtraOptions(t *testing.T) {
+	extraOptions := types.MapValueMust(types.StringType, map[string]attr.Value{
+		"key_usage": types.StringValue("encrypt"),
+		"removed":   types.StringValue("value"),
+	})
+	kmiOptions := map[string]string{
+		"key_usage":      "sign",
+		"key_size_bytes": "16",
+	}
+
+	reconciled := reconcileExtraOptions(extraOptions, kmiOptions)
+	want := types.MapValueMust(types.StringType, map[string]attr.Value{
+		"key_usage": types.StringValue("sign"),
+	})
+	if !reconciled.Equal(want) {
+		t.Errorf("reconcileExtraOptions() = %v, want %v", reconciled, want)
+	}
+
+	if !reconcileExtraOptions(types.MapNull(types.StringType), kmiOptions).IsNull() {
+		t.Errorf("reconcileExtraOptions() of null extra_options should stay null")
+	}
+}
+
+func Test_Definition_AsymmetricKey(t *testing.T) {
+	ak := AsymmetricKey{
+		AutoGenerate:  types.BoolValue(true),
+		ExpiryPeriod:  types.StringValue("1 years"),
+		RefreshPeriod: types.StringValue("6 months"),
+		KeyType:       types.StringValue("ec"),
+		KeySize:       types.Int64Null(),
+		Curve:         types.StringValue("secp384r1"),
+		ExtraOptions:  types.MapNull(types.StringType),
+	}
+	defn, err := ak.RequestPayload(kmi.KMIDefinition{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, _ := xml.MarshalIndent(defn, "", "")
+
+	data := []byte(`<definition type="asymmetric_key"><expire_period>1 years</expire_period><refresh_period>6 months</refresh_period><auto_generate>True</auto_generate><option name="key_type">ec</option><option name="curve">secp384r1</option></definition>`)
+	if !reflect.DeepEqual(string(out), string(data)) {
+		t.Errorf("Marshalling() = %v, want %v", string(out), string(data))
+	}
+}
+
+func Test_Definition_SSHKey(t *testing.T) {
+	sk := SSHKey{
+		AutoGenerate:  types.BoolValue(true),
+		ExpiryPeriod:  types.StringNull(),
+		RefreshPeriod: types.StringNull(),
+		KeyType:       types.StringValue("rsa"),
+		KeySize:       types.Int64Value(4096),
+		ExtraOptions: types.MapValueMust(types.StringType, map[string]attr.Value{
+			"comment": types.StringValue("deploy"),
+		}),
+	}
+	defn, err := sk.RequestPayload(kmi.KMIDefinition{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, _ := xml.MarshalIndent(defn, "", "")
+
+	data := []byte(`<definition type="ssh_key"><auto_generate>True</auto_generate><option name="key_type">rsa</option><option name="key_size">4096</option><option name="comment">deploy</option></definition>`)
+	if !reflect.DeepEqual(string(out), string(data)) {
+		t.Errorf("Marshalling() = %v, want %v", string(out), string(data))
+	}
+}
+
+func Test_Definition_Password(t *testing.T) {
+	pw := Password{
+		AutoGenerate:  types.BoolValue(true),
+		ExpiryPeriod:  types.StringValue("90 days"),
+		RefreshPeriod: types.StringValue("30 days"),
+		Length:        types.Int64Value(32),
+		Charset:       types.StringValue("alphanumeric"),
+		ExtraOptions:  types.MapNull(types.StringType),
+	}
+	defn, err := pw.RequestPayload(kmi.KMIDefinition{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, _ := xml.MarshalIndent(defn, "", "")
+
+	data := []byte(`<definition type="password"><expire_period>90 days</expire_period><refresh_period>30 days</refresh_period><auto_generate>True</auto_generate><option name="length">32</option><option name="charset">alphanumeric</option></definition>`)
+	if !reflect.DeepEqual(string(out), string(data)) {
+		t.Errorf("Marshalling() = %v, want %v", string(out), string(data))
+	}
+}
+
+func Test_Definition_SSLReadResponse(t *testing.T) {
+	data := []byte(`<definition name="ca" type="ssl_cert" modified="355396416">
+	<readers>ca-readers</readers>
+	<auto_generate>True</auto_generate>
+	<expire_period>1 years</expire_period>
+	<refresh_period>6 months</refresh_period>
+	<option name="is_ca">1</option>
+	<option name="subject">CN=ca.example.com</option>
+	<option name="subj_alt_names">ca.example.com,ca.example.net</option>
+	<option name="signacl:web">true</option>
+	<option name="signaclgroup:admins">true</option>
+	<option name="key_size">2048</option>
+	<option name="key_usage">critical</option>
+  </definition>`)
+	var details kmi.KMIDefinitionResponse
+	if err := xml.Unmarshal(data, &details); err != nil {
+		t.Fatal(err)
+	}
+
+	model := definitionResourceModel{
+		SSLCert: &SSLCert{
+			ExpiryPeriod:    types.StringValue("2 years"),
+			RefreshPeriod:   types.StringValue("6 months"),
+			IsCA:            types.Int64Value(0),
+			Subject:         types.StringValue("CN=old.example.com"),
+			Cn:              types.StringValue("stale"),
+			SubjectAltNames: types.SetValueMust(types.StringType, []attr.Value{types.StringValue("old.example.com")}),
+			SubjectAltUris:  types.SetNull(types.StringType),
+			ExtraOptions: types.MapValueMust(types.StringType, map[string]attr.Value{
+				"key_usage": types.StringValue("digitalSignature"),
+			}),
+		},
+	}
+	model.setPermissions(&details)
+	model.SSLCert.ReadResponse(&details, false)
+
+	want := SSLCert{
+		AutoGenerate:  types.BoolValue(true),
+		ExpiryPeriod:  types.StringValue("1 years"),
+		RefreshPeriod: types.StringValue("6 months"),
+		Issuer:        types.StringNull(),
+		IsCA:          types.Int64Value(1),
+		Subject:       types.StringValue("CN=ca.example.com"),
+		Cn:            types.StringNull(),
+		SubjectAltNames: types.SetValueMust(types.StringType, []attr.Value{
+			types.StringValue("ca.example.com"),
+			types.StringValue("ca.example.net"),
+		}),
+		SubjectAltUris: types.SetNull(types.StringType),
+		CAName:         types.StringNull(),
+		SignACL:        types.SetValueMust(types.StringType, []attr.Value{types.StringValue("web")}),
+		SignACLDomain:  types.SetNull(types.StringType),
+		SignACLGroup:   types.SetValueMust(types.StringType, []attr.Value{types.StringValue("admins")}),
+		ExtraOptions: types.MapValueMust(types.StringType, map[string]attr.Value{
+			"key_usage": types.StringValue("critical"),
+		}),
+	}
+	if !reflect.DeepEqual(*model.SSLCert, want) {
+		t.Errorf("ReadResponse() = %+v, want %+v", *model.SSLCert, want)
+	}
+	if !model.Adders.IsNull() || model.Readers.ValueString() != "ca-readers" {
+		t.Errorf("setPermissions() adders = %v, readers = %v", model.Adders, model.Readers)
+	}
+}
+
+func Test_Definition_PasswordReadResponse(t *testing.T) {
+	details := kmi.KMIDefinitionResponse{
+		AutoGenerate: "False",
+		Option: []kmi.KMIDefinitionOption{
+			{Name: "length", Text: "24"},
+			{Name: "charset", Text: "hex"},
+		},
+	}
+
+	pw := Password{
+		Length:       types.Int64Value(32),
+		Charset:      types.StringValue("alphanumeric"),
+		ExtraOptions: types.MapNull(types.StringType),
+	}
+	pw.ReadResponse(&details, false)
+
+	want := Password{
+		AutoGenerate:  types.BoolValue(false),
+		ExpiryPeriod:  types.StringNull(),
+		RefreshPeriod: types.StringNull(),
+		Length:        types.Int64Value(24),
+		Charset:       types.StringValue("hex"),
+		ExtraOptions:  types.MapNull(types.StringType),
+	}
+	if !reflect.DeepEqual(pw, want) {
+		t.Errorf("ReadResponse() = %+v, want %+v", pw, want)
+	}
+}
+
+func Test_Definition_SSLSignACLs(t *testing.T) {
+	s := SSLCert{
+		AutoGenerate: types.BoolValue(true),
+		IsCA:         types.Int64Value(1),
+		SignACL: types.SetValueMust(types.StringType, []attr.Value{
+			types.StringValue("web"),
+			types.StringValue("api"),
+		}),
+		SignACLDomain: types.SetValueMust(types.StringType, []attr.Value{
+			types.StringValue("*.example.com"),
+		}),
+		SignACLGroup: types.SetNull(types.StringType),
+		ExtraOptions: types.MapNull(types.StringType),
+	}
+	defn, err := s.RequestPayload(kmi.KMIDefinition{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, _ := xml.MarshalIndent(defn, "", "")
+
+	data := []byte(`<definition type="ssl_cert"><auto_generate>True</auto_generate><option name="is_ca">1</option><option name="signacl:api">true</option><option name="signacl:web">true</option><option name="signacldomain:*.example.com">true</option></definition>`)
+	if !reflect.DeepEqual(string(out), string(data)) {
+		t.Errorf("Marshalling() = %v, want %v", string(out), string(data))
+	}
+}
+
+func Test_RemovedOptions(t *testing.T) {
+	prior := SSLCert{
+		AutoGenerate: types.BoolValue(true),
+		Cn:           types.StringValue("ca.example.com"),
+		SignACL: types.SetValueMust(types.StringType, []attr.Value{
+			types.StringValue("web"),
+			types.StringValue("api"),
+		}),
+		SignACLGroup: types.SetValueMust(types.StringType, []attr.Value{
+			types.StringValue("admins"),
+		}),
+		ExtraOptions: types.MapNull(types.StringType),
+	}
+	plan := prior
+	plan.SignACL = types.SetValueMust(types.StringType, []attr.Value{
+		types.StringValue("web"),
+		types.StringValue("batch"),
+	})
+	plan.SignACLGroup = types.SetNull(types.StringType)
+
+	removed, err := removedOptions(prior, plan)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"signacl:api", "signaclgroup:admins"}
+	if !reflect.DeepEqual(removed, want) {
+		t.Errorf("removedOptions() = %v, want %v", removed, want)
+	}
+}
+
+func Test_DefinitionUpgradeStateV1(t *testing.T) {
+	ctx := context.Background()
+	r, ok := NewDefinitionsResource().(fwresource.ResourceWithUpgradeState)
+	if !ok {
+		t.Fatal("definitions resource does not implement ResourceWithUpgradeState")
+	}
+
+	req := fwresource.UpgradeStateRequest{
+		RawState: &tfprotov6.RawState{
+			JSON: []byte(`{"name":"ca","collection_name":"col","ssl_cert":{"auto_generate":true,"signacl":"web","signacldomain":null}}`),
+		},
+	}
+	resp := &fwresource.UpgradeStateResponse{}
+	r.UpgradeState(ctx)[1].StateUpgrader(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("UpgradeState() diagnostics = %v", resp.Diagnostics)
+	}
+
+	var upgraded struct {
+		SSLCert map[string]any `json:"ssl_cert"`
+	}
+	if err := json.Unmarshal(resp.DynamicValue.JSON, &upgraded); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(upgraded.SSLCert["signacl"], []any{"web"}) {
+		t.Errorf("UpgradeState() signacl = %v, want %v", upgraded.SSLCert["signacl"], []any{"web"})
+	}
+	if upgraded.SSLCert["signacldomain"] != nil {
+		t.Errorf("UpgradeState() signacldomain = %v, want nil", upgraded.SSLCert["signacldomain"])
+	}
+}
+
+func Test_Definition_SSLSubjectAltNames(t *testing.T) {
+	s := SSLCert{
+		AutoGenerate: types.BoolValue(true),
+		Cn:           types.StringValue("www.example.com"),
+		SubjectAltNames: types.SetValueMust(types.StringType, []attr.Value{
+			types.StringValue("www.example.com"),
+			types.StringValue("*.example.com"),
+		}),
+		SubjectAltUris: types.SetValueMust(types.StringType, []attr.Value{
+			types.StringValue("spiffe://example.com/web"),
+		}),
+		ExtraOptions: types.MapNull(types.StringType),
+	}
+	defn, err := s.RequestPayload(kmi.KMIDefinition{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, _ := xml.MarshalIndent(defn, "", "")
+
+	data := []byte(`<definition type="ssl_cert"><auto_generate>True</auto_generate><option name="cn">www.example.com</option><option name="subj_alt_names">*.example.com,www.example.com</option><option name="subj_alt_uris">spiffe://example.com/web</option></definition>`)
+	if !reflect.DeepEqual(string(out), string(data)) {
+		t.Errorf("Marshalling() = %v, want %v", string(out), string(data))
+	}
+}
+
+func Test_DefinitionUpgradeStateV2(t *testing.T) {
+	ctx := context.Background()
+	r, ok := NewDefinitionsResource().(fwresource.ResourceWithUpgradeState)
+	if !ok {
+		t.Fatal("definitions resource does not implement ResourceWithUpgradeState")
+	}
+
+	req := fwresource.UpgradeStateRequest{
+		RawState: &tfprotov6.RawState{
+			JSON: []byte(`{"name":"web","collection_name":"col","ssl_cert":{"auto_generate":true,"subj_alt_names":"a.example.com, b.example.com","subj_alt_uris":null}}`),
+		},
+	}
+	resp := &fwresource.UpgradeStateResponse{}
+	r.UpgradeState(ctx)[2].StateUpgrader(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("UpgradeState() diagnostics = %v", resp.Diagnostics)
+	}
+
+	var upgraded struct {
+		SSLCert map[string]any `json:"ssl_cert"`
+	}
+	if err := json.Unmarshal(resp.DynamicValue.JSON, &upgraded); err != nil {
+		t.Fatal(err)
+	}
+	want := []any{"a.example.com", "b.example.com"}
+	if !reflect.DeepEqual(upgraded.SSLCert["subj_alt_names"], want) {
+		t.Errorf("UpgradeState() subj_alt_names = %v, want %v", upgraded.SSLCert["subj_alt_names"], want)
+	}
+	if upgraded.SSLCert["subj_alt_uris"] != nil {
+		t.Errorf("UpgradeState() subj_alt_uris = %v, want nil", upgraded.SSLCert["subj_alt_uris"])
+	}
+}
+
+func Test_Definition_ReadResponseOmittedDefaults(t *testing.T) {
+	// KMI fills in periods and key sizes the configuration left out.
+	details := kmi.KMIDefinitionResponse{
+		AutoGenerate:  "True",
+		ExpirePeriod:  "1 years",
+		RefreshPeriod: "6 months",
+		Option: []kmi.KMIDefinitionOption{
+			{Name: "key_size_bytes", Text: "32"},
+			{Name: "key_type", Text: "rsa"},
+			{Name: "key_size", Text: "2048"},
+		},
+	}
+
+	sk := SymetricKey{ExtraOptions: types.MapNull(types.StringType)}
+	sk.ReadResponse(&details, false)
+	if !sk.ExpiryPeriod.IsNull() || !sk.RefreshPeriod.IsNull() || !sk.KeySizeBytes.IsNull() {
+		t.Errorf("ReadResponse() = %+v, want null expire_period, refresh_period and key_size_bytes", sk)
+	}
+
+	ak := AsymmetricKey{
+		RefreshPeriod: types.StringValue("3 months"),
+		KeyType:       types.StringValue("rsa"),
+		ExtraOptions:  types.MapNull(types.StringType),
+	}
+	ak.ReadResponse(&details, false)
+	want := AsymmetricKey{
+		AutoGenerate:  types.BoolValue(true),
+		ExpiryPeriod:  types.StringNull(),
+		RefreshPeriod: types.StringValue("6 months"),
+		KeyType:       types.StringValue("rsa"),
+		KeySize:       types.Int64Null(),
+		Curve:         types.StringNull(),
+		ExtraOptions:  types.MapNull(types.StringType),
+	}
+	if !reflect.DeepEqual(ak, want) {
+		t.Errorf("ReadResponse() = %+v, want %+v", ak, want)
+	}
+}
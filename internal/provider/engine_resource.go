@@ -2,22 +2,29 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io/fs"
 	"reflect"
 	"strings"
 	"terraform-provider-kmi/internal/kmi"
-	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource              = &engineResource{}
-	_ resource.ResourceWithConfigure = &engineResource{}
+	_ resource.Resource                   = &engineResource{}
+	_ resource.ResourceWithConfigure      = &engineResource{}
+	_ resource.ResourceWithValidateConfig = &engineResource{}
+	_ resource.ResourceWithModifyPlan     = &engineResource{}
 )
 
 // NewEngineResource is a helper function to simplify the provider implementation.
@@ -43,28 +50,52 @@ func (r *engineResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 			"engine": schema.StringAttribute{
 				Required:    true,
 				Description: " Authentication engine name to be created on KMI ",
+				Validators: []validator.String{
+					kmiNameValidator(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"account_name": schema.StringAttribute{
 				Required:    true,
 				Description: "The name of the account has been created on KMI ",
+				Validators: []validator.String{
+					kmiNameValidator(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"cloud": schema.StringAttribute{
 				Optional:    true,
 				Description: "Cloud that uses this engine",
 			},
 			"api_endpoint": schema.StringAttribute{
-				Required:    true,
-				Description: "The Kuberenetes API endpoint of the Kuberenetes cluster has been created on KMI ",
+				Optional:    true,
+				Computed:    true,
+				Description: "The Kuberenetes API endpoint of the Kuberenetes cluster has been created on KMI. Derived from kubeconfig when not set ",
 			},
 			"cas_base64": schema.StringAttribute{
-				Required:    true,
-				Description: "The base64 encoded certificate authority of the Kuberenetes cluster has been created on KMI ",
+				Optional:    true,
+				Computed:    true,
+				Description: "The base64 encoded certificate authority of the Kuberenetes cluster has been created on KMI. Derived from kubeconfig when not set ",
+			},
+			"kubeconfig": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Raw kubeconfig content or a path to a kubeconfig file, used instead of api_endpoint and cas_base64 ",
+			},
+			"kubeconfig_context": schema.StringAttribute{
+				Optional:    true,
+				Description: "The kubeconfig context of the cluster. Defaults to the kubeconfig current-context ",
 			},
 			"source": schema.StringAttribute{
 				Optional: true,
 			},
 			"last_updated": schema.StringAttribute{
-				Computed: true,
+				Computed:    true,
+				Description: "The KMI modified timestamp of the engine. ",
 			},
 			"workloads": schema.ListNestedAttribute{
 				Required:    true,
@@ -74,18 +105,30 @@ func (r *engineResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 						"name": schema.StringAttribute{
 							Required:    true,
 							Description: "The name of the workload has been created on KMI ",
+							Validators: []validator.String{
+								kmiNameValidator(),
+							},
 						},
 						"serviceaccount": schema.StringAttribute{
 							Required:    true,
 							Description: "The Kubernetes service account ",
+							Validators: []validator.String{
+								kubernetesServiceAccountValidator(),
+							},
 						},
 						"namespace": schema.StringAttribute{
 							Required:    true,
 							Description: "The Kubernetes namespace to which workload belongs to ",
+							Validators: []validator.String{
+								kubernetesNamespaceValidator(),
+							},
 						},
 						"region": schema.StringAttribute{
 							Required:    true,
 							Description: "The Linode region to which cluster belongs to curl -s https://api.linode.com/v4/regions/ | jq .data[].id ",
+							Validators: []validator.String{
+								linodeRegionValidator(),
+							},
 						},
 					},
 				},
@@ -94,6 +137,86 @@ func (r *engineResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 	}
 }
 
+// ValidateConfig ensures the cluster is configured either through kubeconfig or
+// through api_endpoint and cas_base64.
+func (r *engineResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config EngineResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.Kubeconfig.IsUnknown() || config.ApiEndpoint.IsUnknown() || config.CertificateDataAuthority.IsUnknown() {
+		return
+	}
+
+	if !config.Kubeconfig.IsNull() {
+		if !config.ApiEndpoint.IsNull() || !config.CertificateDataAuthority.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("kubeconfig"),
+				"Conflicting Kubernetes cluster configuration",
+				"kubeconfig cannot be set together with api_endpoint or cas_base64",
+			)
+		}
+		return
+	}
+
+	if !config.KubeconfigContext.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("kubeconfig_context"),
+			"Missing kubeconfig",
+			"kubeconfig_context can only be set together with kubeconfig",
+		)
+	}
+	if config.ApiEndpoint.IsNull() || config.CertificateDataAuthority.IsNull() {
+		resp.Diagnostics.AddError(
+			"Missing Kubernetes cluster configuration",
+			"Either kubeconfig or both api_endpoint and cas_base64 must be set",
+		)
+		return
+	}
+	if err := validateCABundle(config.CertificateDataAuthority.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("cas_base64"),
+			"Invalid certificate authority",
+			err.Error(),
+		)
+	}
+}
+
+// ModifyPlan derives api_endpoint and cas_base64 from kubeconfig, so a change
+// of the kubeconfig content or file is planned like any other change. A
+// kubeconfig file which does not exist yet is resolved during apply.
+func (r *engineResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	var plan EngineResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan.Kubeconfig.IsNull() || plan.Kubeconfig.IsUnknown() || plan.KubeconfigContext.IsUnknown() {
+		return
+	}
+
+	plan.ApiEndpoint = types.StringUnknown()
+	plan.CertificateDataAuthority = types.StringUnknown()
+	err := plan.resolveCluster()
+	missingFile := errors.Is(err, fs.ErrNotExist) && !isKubeconfigContent(plan.Kubeconfig.ValueString())
+	if err != nil && !missingFile {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("kubeconfig"),
+			"Invalid Kubernetes cluster configuration",
+			"Could not resolve the Kubernetes cluster, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("api_endpoint"), plan.ApiEndpoint)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("cas_base64"), plan.CertificateDataAuthority)...)
+}
+
 // Create creates the resource and sets the initial Terraform state.
 func (r *engineResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan EngineResourceModel
@@ -105,6 +228,15 @@ func (r *engineResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	err := plan.resolveCluster()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Kubernetes cluster configuration",
+			"Could not resolve the Kubernetes cluster, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
 	options := []kmi.KMIOption{{
 		Text: plan.CertificateDataAuthority.ValueString(),
 		Name: "cas_base64",
@@ -129,7 +261,7 @@ func (r *engineResource) Create(ctx context.Context, req resource.CreateRequest,
 		Option:    options,
 		Workloads: workloads,
 	}
-	err := r.client.SaveIdentityEngine(plan.AccountName.ValueString(), plan.Engine.ValueString(), engine)
+	err = r.client.SaveIdentityEngine(plan.AccountName.ValueString(), plan.Engine.ValueString(), engine)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating Identity Engine",
@@ -139,7 +271,15 @@ func (r *engineResource) Create(ctx context.Context, req resource.CreateRequest,
 	}
 	tflog.Debug(ctx, "After Saving Identity engine")
 
-	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+	identityEngine, err := r.client.GetIdentityEngine(plan.AccountName.ValueString(), plan.Engine.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Identity Engine",
+			"Could not get Identity, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	plan.LastUpdated = types.StringValue(identityEngine.Modified)
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, plan)
@@ -209,6 +349,7 @@ func (r *engineResource) Read(ctx context.Context, req resource.ReadRequest, res
 	if reflect.DeepEqual(workloads, state.Workloads) {
 		state.Workloads = workloads
 	}
+	state.LastUpdated = types.StringValue(identityEngine.Modified)
 
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -228,6 +369,15 @@ func (r *engineResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	err := plan.resolveCluster()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Kubernetes cluster configuration",
+			"Could not resolve the Kubernetes cluster, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
 	options := []kmi.KMIOption{{
 		Text: plan.CertificateDataAuthority.ValueString(),
 		Name: "cas_base64",
@@ -252,7 +402,7 @@ func (r *engineResource) Update(ctx context.Context, req resource.UpdateRequest,
 		Option:    options,
 		Workloads: workloads,
 	}
-	err := r.client.SaveIdentityEngine(plan.AccountName.ValueString(), plan.Engine.ValueString(), engine)
+	err = r.client.SaveIdentityEngine(plan.AccountName.ValueString(), plan.Engine.ValueString(), engine)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating Identity Engine",
@@ -298,8 +448,7 @@ func (r *engineResource) Update(ctx context.Context, req resource.UpdateRequest,
 	if reflect.DeepEqual(workloadModels, plan.Workloads) {
 		plan.Workloads = workloadModels
 	}
-	// plan.LastUpdated = identityEngine.Published
-	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+	plan.LastUpdated = types.StringValue(identityEngine.Modified)
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, plan)
@@ -384,11 +533,28 @@ type EngineResourceModel struct {
 	Cloud                    types.String            `tfsdk:"cloud"`
 	ApiEndpoint              types.String            `tfsdk:"api_endpoint"`
 	CertificateDataAuthority types.String            `tfsdk:"cas_base64"`
+	Kubeconfig               types.String            `tfsdk:"kubeconfig"`
+	KubeconfigContext        types.String            `tfsdk:"kubeconfig_context"`
 	Source                   types.String            `tfsdk:"source"`
 	Workloads                []WorkloadResourceModel `tfsdk:"workloads"`
 	LastUpdated              types.String            `tfsdk:"last_updated"`
 }
 
+// resolveCluster derives api_endpoint and cas_base64 from kubeconfig when it is
+// set and they were not planned yet, and checks that the certificate authority
+// is a valid PEM certificate chain.
+func (m *EngineResourceModel) resolveCluster() error {
+	if !m.Kubeconfig.IsNull() && (m.ApiEndpoint.IsUnknown() || m.CertificateDataAuthority.IsUnknown()) {
+		endpoint, casBase64, err := kubeconfigCluster(m.Kubeconfig.ValueString(), m.KubeconfigContext.ValueString())
+		if err != nil {
+			return err
+		}
+		m.ApiEndpoint = types.StringValue(endpoint)
+		m.CertificateDataAuthority = types.StringValue(casBase64)
+	}
+	return validateCABundle(m.CertificateDataAuthority.ValueString())
+}
+
 type WorkloadResourceModel struct {
 	Name           types.String `tfsdk:"name"`
 	ServiceAccount types.String `tfsdk:"serviceaccount"`
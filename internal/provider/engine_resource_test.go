@@ -2,9 +2,15 @@ package provider
 
 import (
 	"context"
+	"encoding/base64"
+	"os"
+	"path/filepath"
 	"testing"
 
 	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
 func TestEngineResourceSchema(t *testing.T) {
@@ -28,3 +34,76 @@ func TestEngineResourceSchema(t *testing.T) {
 		t.Fatalf("Schema validation diagnostics: %+v", diagnostics)
 	}
 }
+
+func TestEngineResourceModifyPlan(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	r := &engineResource{}
+	schemaResponse := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResponse)
+
+	oldCAs := base64.StdEncoding.EncodeToString(testCAPem(t))
+	newCAs := base64.StdEncoding.EncodeToString(testCAPem(t))
+	kubeconfigPath := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(kubeconfigPath, []byte(testKubeconfig(newCAs)), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		kubeconfig   string
+		wantEndpoint types.String
+		wantCAs      types.String
+		wantError    bool
+	}{
+		{"changed kubeconfig file", kubeconfigPath, types.StringValue("https://prod.example.com:443"), types.StringValue(newCAs), false},
+		{"missing kubeconfig file", filepath.Join(t.TempDir(), "missing"), types.StringUnknown(), types.StringUnknown(), false},
+		{"invalid kubeconfig", "apiVersion: v1\nkind: Config\n", types.StringValue("https://prod.example.com:443"), types.StringValue(oldCAs), true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// The prior state was planned from the kubeconfig before its
+			// certificate authority was rotated.
+			plan := tfsdk.Plan{
+				Schema: schemaResponse.Schema,
+				Raw:    tftypes.NewValue(schemaResponse.Schema.Type().TerraformType(ctx), nil),
+			}
+			diags := plan.Set(ctx, &EngineResourceModel{
+				Engine:                   types.StringValue("prod"),
+				AccountName:              types.StringValue("PIM_TEST"),
+				Cloud:                    types.StringNull(),
+				ApiEndpoint:              types.StringValue("https://prod.example.com:443"),
+				CertificateDataAuthority: types.StringValue(oldCAs),
+				Kubeconfig:               types.StringValue(tt.kubeconfig),
+				KubeconfigContext:        types.StringNull(),
+				Source:                   types.StringNull(),
+				LastUpdated:              types.StringValue("2024-01-01"),
+			})
+			if diags.HasError() {
+				t.Fatalf("Plan.Set() diagnostics = %v", diags)
+			}
+
+			resp := &fwresource.ModifyPlanResponse{Plan: plan}
+			r.ModifyPlan(ctx, fwresource.ModifyPlanRequest{Plan: plan}, resp)
+
+			if resp.Diagnostics.HasError() != tt.wantError {
+				t.Fatalf("ModifyPlan() diagnostics = %v, want error %v", resp.Diagnostics, tt.wantError)
+			}
+			var got EngineResourceModel
+			if diags := resp.Plan.Get(ctx, &got); diags.HasError() {
+				t.Fatalf("Plan.Get() diagnostics = %v", diags)
+			}
+			if !got.ApiEndpoint.Equal(tt.wantEndpoint) {
+				t.Errorf("api_endpoint = %v, want %v", got.ApiEndpoint, tt.wantEndpoint)
+			}
+			if !got.CertificateDataAuthority.Equal(tt.wantCAs) {
+				t.Errorf("cas_base64 = %v, want %v", got.CertificateDataAuthority, tt.wantCAs)
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// groupMemberTypes are the kinds of members a KMI group can hold.
+var groupMemberTypes = []string{"group", "user", "workload", "machine"}
+
+var _ validator.Object = groupMemberValidator{}
+
+// groupMemberAttributes returns the attributes describing a member of a group.
+func groupMemberAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"type": schema.StringAttribute{
+			Optional:    true,
+			Description: "The kind of member, one of \"group\", \"user\", \"workload\" or \"machine\". Without a type, name is used as the KMI member name as is. ",
+			Validators: []validator.String{
+				stringOneOf(groupMemberTypes...),
+			},
+		},
+		"name": schema.StringAttribute{
+			Optional:    true,
+			Description: "The name of the group, user or machine. Without a type, the complete KMI member name such as \"user:NAME\". ",
+		},
+		"account": schema.StringAttribute{
+			Optional:    true,
+			Description: "The KMI account of the workload or machine. ",
+		},
+		"engine": schema.StringAttribute{
+			Optional:    true,
+			Description: "The identity engine of the workload. ",
+		},
+		"projection": schema.StringAttribute{
+			Optional:    true,
+			Description: "The projection of the workload. ",
+		},
+	}
+}
+
+// Member is a member of a KMI group.
+type Member struct {
+	Type       types.String `tfsdk:"type"`
+	Name       types.String `tfsdk:"name"`
+	Account    types.String `tfsdk:"account"`
+	Engine     types.String `tfsdk:"engine"`
+	Projection types.String `tfsdk:"projection"`
+}
+
+// memberFields returns the attributes a member of the given type is made of.
+func memberFields(memberType string) []string {
+	switch memberType {
+	case "workload":
+		return []string{"account", "engine", "projection"}
+	case "machine":
+		return []string{"account", "name"}
+	}
+	return []string{"name"}
+}
+
+func (m Member) fields() map[string]types.String {
+	return map[string]types.String{
+		"name":       m.Name,
+		"account":    m.Account,
+		"engine":     m.Engine,
+		"projection": m.Projection,
+	}
+}
+
+// childName returns the KMI name of the member, such as "PIM_ADMIN",
+// "user:NAME" or "workload:ACCOUNT:ENGINE:PROJECTION". It reports false while
+// any part of the name is unknown.
+func (m Member) childName() (string, bool) {
+	if m.Type.IsUnknown() {
+		return "", false
+	}
+	fields := m.fields()
+	parts := []string{}
+	if !m.Type.IsNull() && m.Type.ValueString() != "group" {
+		parts = append(parts, m.Type.ValueString())
+	}
+	for _, field := range memberFields(m.Type.ValueString()) {
+		if fields[field].IsUnknown() {
+			return "", false
+		}
+		parts = append(parts, fields[field].ValueString())
+	}
+	return strings.Join(parts, ":"), true
+}
+
+// validate checks that the member sets exactly the attributes of its type.
+func (m Member) validate() error {
+	if m.Type.IsUnknown() {
+		return nil
+	}
+	memberType := m.Type.ValueString()
+	required := map[string]bool{}
+	for _, field := range memberFields(memberType) {
+		required[field] = true
+	}
+	for _, field := range []string{"name", "account", "engine", "projection"} {
+		value := m.fields()[field]
+		switch {
+		case value.IsUnknown():
+		case required[field] && value.IsNull():
+			if m.Type.IsNull() {
+				return fmt.Errorf("a member without a type requires %s", field)
+			}
+			return fmt.Errorf("a %s member requires %s", memberType, field)
+		case !required[field] && !value.IsNull():
+			if m.Type.IsNull() {
+				return fmt.Errorf("a member without a type only sets name, got %s", field)
+			}
+			return fmt.Errorf("a %s member does not use %s", memberType, field)
+		case required[field] && value.ValueString() == "":
+			return fmt.Errorf("%s of the member must not be empty", field)
+		case required[field] && !m.Type.IsNull() && strings.Contains(value.ValueString(), ":"):
+			return fmt.Errorf("%s of a %s member must not contain \":\", got %q", field, memberType, value.ValueString())
+		}
+	}
+	return nil
+}
+
+// parseMemberName returns the member KMI refers to by name, typed whenever
+// the name follows one of the prefixed member name formats. Bare names stay
+// untyped, matching the documented name = "GROUP" form.
+func parseMemberName(name string) Member {
+	member := Member{
+		Type:       types.StringNull(),
+		Name:       types.StringNull(),
+		Account:    types.StringNull(),
+		Engine:     types.StringNull(),
+		Projection: types.StringNull(),
+	}
+	parts := strings.Split(name, ":")
+	switch {
+	case len(parts) == 2 && parts[0] == "user":
+		member.Type = types.StringValue("user")
+		member.Name = types.StringValue(parts[1])
+	case len(parts) == 3 && parts[0] == "machine":
+		member.Type = types.StringValue("machine")
+		member.Account = types.StringValue(parts[1])
+		member.Name = types.StringValue(parts[2])
+	case len(parts) == 4 && parts[0] == "workload":
+		member.Type = types.StringValue("workload")
+		member.Account = types.StringValue(parts[1])
+		member.Engine = types.StringValue(parts[2])
+		member.Projection = types.StringValue(parts[3])
+	default:
+		member.Name = types.StringValue(name)
+	}
+	return member
+}
+
+// groupMemberValidator checks that a member sets exactly the attributes of its type.
+type groupMemberValidator struct{}
+
+func (v groupMemberValidator) Description(_ context.Context) string {
+	return "group members set the attributes of their type: name for groups and users, account and name for machines, account, engine and projection for workloads"
+}
+
+func (v groupMemberValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v groupMemberValidator) ValidateObject(ctx context.Context, req validator.ObjectRequest, resp *validator.ObjectResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	var member Member
+	resp.Diagnostics.Append(req.ConfigValue.As(ctx, &member, basetypes.ObjectAsOptions{})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if err := member.validate(); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Group Member", err.Error())
+	}
+}
@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func testMember(memberType string, fields map[string]string) Member {
+	value := func(name string) types.String {
+		if v, ok := fields[name]; ok {
+			return types.StringValue(v)
+		}
+		return types.StringNull()
+	}
+	member := Member{
+		Type:       types.StringNull(),
+		Name:       value("name"),
+		Account:    value("account"),
+		Engine:     value("engine"),
+		Projection: value("projection"),
+	}
+	if memberType != "" {
+		member.Type = types.StringValue(memberType)
+	}
+	return member
+}
+
+func TestMemberChildName(t *testing.T) {
+	t.Parallel()
+
+	unknownEngine := testMember("workload", map[string]string{"account": "PIM_TEST", "projection": "app"})
+	unknownEngine.Engine = types.StringUnknown()
+
+	tests := []struct {
+		name      string
+		member    Member
+		want      string
+		wantKnown bool
+	}{
+		{"group", testMember("group", map[string]string{"name": "PIM_ADMIN"}), "PIM_ADMIN", true},
+		{"user", testMember("user", map[string]string{"name": "hachandr"}), "user:hachandr", true},
+		{"workload", testMember("workload", map[string]string{"account": "PIM_TEST", "engine": "pi-logs-dev", "projection": "pi-etp-processor"}), "workload:PIM_TEST:pi-logs-dev:pi-etp-processor", true},
+		{"machine", testMember("machine", map[string]string{"account": "PIM_TEST", "name": "host1"}), "machine:PIM_TEST:host1", true},
+		{"untyped", testMember("", map[string]string{"name": "user:hachandr"}), "user:hachandr", true},
+		{"unknown part", unknownEngine, "", false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, known := tt.member.childName()
+			if got != tt.want || known != tt.wantKnown {
+				t.Errorf("childName() = %q, %v, want %q, %v", got, known, tt.want, tt.wantKnown)
+			}
+		})
+	}
+}
+
+func TestParseMemberName(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range []string{
+		"PIM_ADMIN",
+		"user:hachandr",
+		"workload:PIM_TEST:pi-logs-dev:pi-etp-processor",
+		"machine:PIM_TEST:host1",
+		"service:something:else",
+	} {
+		member := parseMemberName(name)
+		if got, _ := member.childName(); got != name {
+			t.Errorf("parseMemberName(%q).childName() = %q", name, got)
+		}
+		if err := member.validate(); err != nil {
+			t.Errorf("parseMemberName(%q).validate() = %v", name, err)
+		}
+	}
+
+	if got := parseMemberName("workload:PIM_TEST:pi-logs-dev:pi-etp-processor"); !reflect.DeepEqual(got, testMember("workload", map[string]string{"account": "PIM_TEST", "engine": "pi-logs-dev", "projection": "pi-etp-processor"})) {
+		t.Errorf("parseMemberName() = %+v, want a workload", got)
+	}
+}
+
+func TestGroupMemberValidator(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		member    Member
+		wantError bool
+	}{
+		{"group", testMember("group", map[string]string{"name": "PIM_ADMIN"}), false},
+		{"untyped", testMember("", map[string]string{"name": "workload:PIM_TEST:engine:app"}), false},
+		{"workload", testMember("workload", map[string]string{"account": "PIM_TEST", "engine": "engine", "projection": "app"}), false},
+		{"workload without projection", testMember("workload", map[string]string{"account": "PIM_TEST", "engine": "engine"}), true},
+		{"workload with name", testMember("workload", map[string]string{"account": "PIM_TEST", "engine": "engine", "projection": "app", "name": "app"}), true},
+		{"machine without account", testMember("machine", map[string]string{"name": "host1"}), true},
+		{"user with account", testMember("user", map[string]string{"name": "hachandr", "account": "PIM_TEST"}), true},
+		{"untyped with engine", testMember("", map[string]string{"name": "app", "engine": "engine"}), true},
+		{"untyped without name", testMember("", map[string]string{}), true},
+		{"empty name", testMember("user", map[string]string{"name": ""}), true},
+		{"colon in typed part", testMember("user", map[string]string{"name": "user:hachandr"}), true},
+	}
+
+	attrTypes := map[string]attr.Type{
+		"type":       types.StringType,
+		"name":       types.StringType,
+		"account":    types.StringType,
+		"engine":     types.StringType,
+		"projection": types.StringType,
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			value, diags := types.ObjectValueFrom(context.Background(), attrTypes, tt.member)
+			if diags.HasError() {
+				t.Fatalf("ObjectValueFrom() diagnostics = %v", diags)
+			}
+			req := validator.ObjectRequest{
+				Path:        path.Root("member"),
+				ConfigValue: value,
+			}
+			resp := &validator.ObjectResponse{}
+			groupMemberValidator{}.ValidateObject(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() != tt.wantError {
+				t.Errorf("ValidateObject() error = %v, want %v", resp.Diagnostics, tt.wantError)
+			}
+		})
+	}
+}
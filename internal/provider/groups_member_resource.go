@@ -0,0 +1,191 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"terraform-provider-kmi/internal/kmi"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &groupMemberResource{}
+	_ resource.ResourceWithConfigure   = &groupMemberResource{}
+	_ resource.ResourceWithImportState = &groupMemberResource{}
+)
+
+func NewGroupMemberResource() resource.Resource {
+	return &groupMemberResource{}
+}
+
+// groupMemberResource adds a single member to a KMI group, leaving the other
+// members of the group alone.
+type groupMemberResource struct {
+	client *kmi.KMIRestClient
+}
+
+// Metadata returns the resource type name.
+func (r *groupMemberResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_group_member"
+}
+
+// Schema defines the schema for the resource.
+func (r *groupMemberResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Adds a single member to a KMI group without managing its other members. Do not combine it with a kmi_group_membership of the same group, which removes every member it does not list.",
+		Attributes: map[string]schema.Attribute{
+			"group_name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the group the member is added to. ",
+				Validators: []validator.String{
+					kmiNameValidator(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"member": schema.SingleNestedAttribute{
+				Required:    true,
+				Description: "The member added to the group. ",
+				Attributes:  groupMemberAttributes(),
+				Validators: []validator.Object{
+					groupMemberValidator{},
+				},
+				PlanModifiers: []planmodifier.Object{
+					requiresReplaceIfMemberChanged(),
+				},
+			},
+		},
+	}
+}
+
+// Create adds the member to the group and sets the initial Terraform state.
+func (r *groupMemberResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan groupMemberModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	member, _ := plan.Member.childName()
+	err := r.client.CreateGroupMembership(plan.GroupName.ValueString(), member)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating Group Membership",
+			"Could not add "+member+" to group "+plan.GroupName.ValueString()+", unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read removes the member from the Terraform state once it left the group.
+func (r *groupMemberResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state groupMemberModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	members, err := r.client.GetGroupMembers(state.GroupName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Group Membership",
+			"Could not read members of group "+state.GroupName.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	name, _ := state.Member.childName()
+	for _, member := range members {
+		if member == name {
+			diags = resp.State.Set(ctx, &state)
+			resp.Diagnostics.Append(diags...)
+			return
+		}
+	}
+	resp.State.RemoveResource(ctx)
+}
+
+// Update records a different spelling of the same member, every other change
+// replaces the membership.
+func (r *groupMemberResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan groupMemberModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete removes the member from the group.
+func (r *groupMemberResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state groupMemberModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	member, _ := state.Member.childName()
+	err := r.client.DeleteGroupMembership(state.GroupName.ValueString(), member)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting Group Membership",
+			"Could not remove "+member+" from group "+state.GroupName.ValueString()+", unexpected error: "+err.Error(),
+		)
+	}
+}
+
+// ImportState imports a membership by its group_name/member identifier, where
+// member is the KMI name of the member such as "workload:ACCOUNT:ENGINE:PROJECTION".
+func (r *groupMemberResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	groupName, member, ok := strings.Cut(req.ID, "/")
+	if !ok || groupName == "" || member == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: group_name/member. Got: %q", req.ID),
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("group_name"), groupName)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("member"), parseMemberName(member))...)
+}
+
+func (r *groupMemberResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*kmi.KMIRestClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *KMIRestClient., got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+type groupMemberModel struct {
+	GroupName types.String `tfsdk:"group_name"`
+	Member    Member       `tfsdk:"member"`
+}
@@ -0,0 +1,72 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestGroupMemberResourceSchema(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	schemaResponse := &fwresource.SchemaResponse{}
+	NewGroupMemberResource().Schema(ctx, fwresource.SchemaRequest{}, schemaResponse)
+
+	if schemaResponse.Diagnostics.HasError() {
+		t.Fatalf("Schema method diagnostics: %+v", schemaResponse.Diagnostics)
+	}
+	if diagnostics := schemaResponse.Schema.ValidateImplementation(ctx); diagnostics.HasError() {
+		t.Fatalf("Schema validation diagnostics: %+v", diagnostics)
+	}
+}
+
+func TestGroupMemberResourceImportState(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	r := NewGroupMemberResource().(fwresource.ResourceWithImportState)
+	schemaResponse := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResponse)
+	newResponse := func() *fwresource.ImportStateResponse {
+		return &fwresource.ImportStateResponse{
+			State: tfsdk.State{
+				Schema: schemaResponse.Schema,
+				Raw:    tftypes.NewValue(schemaResponse.Schema.Type().TerraformType(ctx), nil),
+			},
+		}
+	}
+
+	resp := newResponse()
+	r.ImportState(ctx, fwresource.ImportStateRequest{ID: "PIM_ADMIN/user:hachandr"}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("ImportState() diagnostics = %v", resp.Diagnostics)
+	}
+	var groupName types.String
+	var member Member
+	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("group_name"), &groupName)...)
+	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("member"), &member)...)
+	if groupName.ValueString() != "PIM_ADMIN" || member.Type.ValueString() != "user" || member.Name.ValueString() != "hachandr" {
+		t.Errorf("ImportState() group_name = %v, member = %v", groupName, member)
+	}
+
+	resp = newResponse()
+	r.ImportState(ctx, fwresource.ImportStateRequest{ID: "PIM_ADMIN/PIM_OWNERS"}, resp)
+	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("member"), &member)...)
+	if resp.Diagnostics.HasError() || !member.Type.IsNull() || member.Name.ValueString() != "PIM_OWNERS" {
+		t.Errorf("ImportState() member = %+v, want the untyped name PIM_OWNERS", member)
+	}
+
+	for _, id := range []string{"PIM_ADMIN", "PIM_ADMIN/", "/user:hachandr"} {
+		resp = newResponse()
+		r.ImportState(ctx, fwresource.ImportStateRequest{ID: id}, resp)
+		if !resp.Diagnostics.HasError() {
+			t.Errorf("ImportState(%q) succeeded, want error", id)
+		}
+	}
+}
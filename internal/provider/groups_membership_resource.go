@@ -7,16 +7,22 @@ import (
 	"terraform-provider-kmi/internal/kmi"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource              = &groupsMembershipResource{}
-	_ resource.ResourceWithConfigure = &groupsMembershipResource{}
+	_ resource.Resource               = &groupsMembershipResource{}
+	_ resource.ResourceWithConfigure  = &groupsMembershipResource{}
+	_ resource.ResourceWithModifyPlan = &groupsMembershipResource{}
 )
 
 // NewOrderResource is a helper function to simplify the provider implementation.
@@ -37,23 +43,33 @@ func (r *groupsMembershipResource) Metadata(_ context.Context, req resource.Meta
 // Schema defines the schema for the resource.
 func (r *groupsMembershipResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Description: "Manages the complete membership of a KMI group. Members of the group which are not listed are removed, use kmi_group_member to add a single member without managing the whole group.",
 		Attributes: map[string]schema.Attribute{
 			"group_name": schema.StringAttribute{
 				Required:    true,
 				Description: "The name of the group to create. ",
+				Validators: []validator.String{
+					kmiNameValidator(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 
 			"last_updated": schema.StringAttribute{
 				Computed:    true,
 				Description: "The last time the group was updated. ",
+				PlanModifiers: []planmodifier.String{
+					useStateForUnknownUnlessChanged(path.Root("members")),
+				},
 			},
 			"members": schema.ListNestedAttribute{
-				Required: true,
+				Required:    true,
+				Description: "Every member of the group. ",
 				NestedObject: schema.NestedAttributeObject{
-					Attributes: map[string]schema.Attribute{
-						"name": schema.StringAttribute{
-							Required: true,
-						},
+					Attributes: groupMemberAttributes(),
+					Validators: []validator.Object{
+						groupMemberValidator{},
 					},
 				},
 			},
@@ -61,6 +77,49 @@ func (r *groupsMembershipResource) Schema(_ context.Context, _ resource.SchemaRe
 	}
 }
 
+// ModifyPlan refuses memberships which would make the group a member of itself,
+// directly or through the groups it contains.
+func (r *groupsMembershipResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+	var plan groupsMembershipModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.GroupName.IsUnknown() {
+		return
+	}
+
+	var members []string
+	for _, member := range plan.Members {
+		if name, known := member.childName(); known {
+			members = append(members, name)
+		}
+	}
+	cycle := findMembershipCycle(plan.GroupName.ValueString(), members, func(group string) []string {
+		children, err := r.client.GetGroupMembers(group)
+		if err == nil {
+			return children
+		}
+		// A group which does not exist yet has no members to form a cycle with.
+		if exists, existsErr := r.client.GroupExists(group); existsErr == nil && !exists {
+			return nil
+		}
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("members"),
+			"Could not verify Group Membership",
+			fmt.Sprintf("Could not read members of group %s, so memberships through it are not checked for cycles, unexpected error: %s", group, err.Error()),
+		)
+		return nil
+	})
+	if cycle != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("members"),
+			"Circular Group Membership",
+			fmt.Sprintf("Group %s would become a member of itself: %s", plan.GroupName.ValueString(), strings.Join(cycle, " -> ")),
+		)
+	}
+}
+
 // Create creates the resource and sets the initial Terraform state.
 func (r *groupsMembershipResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan groupsMembershipModel
@@ -71,20 +130,8 @@ func (r *groupsMembershipResource) Create(ctx context.Context, req resource.Crea
 	}
 	tflog.Info(ctx, "Create Groups Request")
 
-	var errstrings []string
-
-	for _, member := range plan.Members {
-		err := r.client.CreateGroupMembership(plan.GroupName.ValueString(), member.Name.ValueString())
-		if err != nil {
-			errstrings = append(errstrings, err.Error())
-		}
-	}
-
-	if errstrings != nil {
-		resp.Diagnostics.AddError(
-			"Error creating Group Membership",
-			"Could not create Group Membership, unexpected error: "+strings.Join(errstrings, "\n"),
-		)
+	r.reconcileMembers(plan.GroupName.ValueString(), plan.Members, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
@@ -108,15 +155,16 @@ func (r *groupsMembershipResource) Read(ctx context.Context, req resource.ReadRe
 		return
 	}
 
-	// Get refreshed order value from KMI Group
-	_, err := r.client.GetGroup(state.GroupName.ValueString())
+	// Get refreshed membership from KMI Group
+	members, err := r.client.GetGroupMembers(state.GroupName.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Error Reading Groups",
-			"Could not read Groups "+state.GroupName.ValueString()+": "+err.Error(),
+			"Error Reading Group Membership",
+			"Could not read members of group "+state.GroupName.ValueString()+": "+err.Error(),
 		)
 		return
 	}
+	state.Members = refreshMembers(state.Members, members)
 
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -135,22 +183,15 @@ func (r *groupsMembershipResource) Update(ctx context.Context, req resource.Upda
 		return
 	}
 
-	var errstrings []string
-
-	for _, member := range plan.Members {
-		err := r.client.CreateGroupMembership(plan.GroupName.ValueString(), member.Name.ValueString())
-		if err != nil {
-			errstrings = append(errstrings, err.Error())
-		}
-	}
-	if errstrings != nil {
-		resp.Diagnostics.AddError(
-			"Error creating Group",
-			"Could not create Group, unexpected error: "+strings.Join(errstrings, "\n"),
-		)
+	r.reconcileMembers(plan.GroupName.ValueString(), plan.Members, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
 		return
 	}
-	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+	// KMI keeps no modified timestamp, so last_updated only moves when the plan
+	// changes the members.
+	if plan.LastUpdated.IsUnknown() {
+		plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+	}
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, plan)
@@ -170,9 +211,8 @@ func (r *groupsMembershipResource) Delete(ctx context.Context, req resource.Dele
 		return
 	}
 
-	for _, member := range state.Members {
-		err := r.client.DeleteGroupMembership(state.GroupName.ValueString(), member.Name.ValueString())
-		//err := r.client.DeleteGroup(state.GroupName.ValueString())
+	for _, member := range memberNames(state.Members) {
+		err := r.client.DeleteGroupMembership(state.GroupName.ValueString(), member)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error Deleting KMI Group",
@@ -183,6 +223,38 @@ func (r *groupsMembershipResource) Delete(ctx context.Context, req resource.Dele
 	}
 }
 
+// reconcileMembers adds and removes members of the group in KMI until its
+// membership matches want.
+func (r *groupsMembershipResource) reconcileMembers(groupName string, want []Member, diags *diag.Diagnostics) {
+	current, err := r.client.GetGroupMembers(groupName)
+	if err != nil {
+		diags.AddError(
+			"Error Reading Group Membership",
+			"Could not read members of group "+groupName+": "+err.Error(),
+		)
+		return
+	}
+
+	added, removed := diffMembers(memberNames(want), current)
+	var errstrings []string
+	for _, member := range added {
+		if err := r.client.CreateGroupMembership(groupName, member); err != nil {
+			errstrings = append(errstrings, err.Error())
+		}
+	}
+	for _, member := range removed {
+		if err := r.client.DeleteGroupMembership(groupName, member); err != nil {
+			errstrings = append(errstrings, err.Error())
+		}
+	}
+	if errstrings != nil {
+		diags.AddError(
+			"Error updating Group Membership",
+			"Could not update Group Membership, unexpected error: "+strings.Join(errstrings, "\n"),
+		)
+	}
+}
+
 func (r *groupsMembershipResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -207,6 +279,95 @@ type groupsMembershipModel struct {
 	Members     []Member     `tfsdk:"members"`
 	LastUpdated types.String `tfsdk:"last_updated"`
 }
-type Member struct {
-	Name types.String `tfsdk:"name"`
+
+func memberNames(members []Member) []string {
+	names := make([]string, 0, len(members))
+	for _, member := range members {
+		name, _ := member.childName()
+		names = append(names, name)
+	}
+	return names
+}
+
+// diffMembers returns the members of want missing from current, and the
+// members of current missing from want.
+func diffMembers(want []string, current []string) (added []string, removed []string) {
+	wanted := map[string]bool{}
+	for _, member := range want {
+		wanted[member] = true
+	}
+	existing := map[string]bool{}
+	for _, member := range current {
+		existing[member] = true
+		if !wanted[member] {
+			removed = append(removed, member)
+		}
+	}
+	for _, member := range want {
+		if !existing[member] {
+			added = append(added, member)
+			existing[member] = true
+		}
+	}
+	return added, removed
+}
+
+// refreshMembers returns the members KMI reports for the group. Members which
+// are still in the group keep their position in prior, so a refresh only
+// differs from the configuration when the membership changed outside Terraform.
+func refreshMembers(prior []Member, current []string) []Member {
+	remaining := map[string]bool{}
+	for _, member := range current {
+		remaining[member] = true
+	}
+	members := []Member{}
+	for _, member := range prior {
+		if name, _ := member.childName(); remaining[name] {
+			members = append(members, member)
+			delete(remaining, name)
+		}
+	}
+	for _, member := range current {
+		if remaining[member] {
+			members = append(members, parseMemberName(member))
+			delete(remaining, member)
+		}
+	}
+	return members
+}
+
+// findMembershipCycle returns the path through which group would contain
+// itself once its members are members, or nil when there is none. membersOf
+// returns the current members of the other groups. Only groups hold members,
+// so members with a typed name such as "user:NAME" end the path.
+func findMembershipCycle(group string, members []string, membersOf func(string) []string) []string {
+	fetched := map[string][]string{group: members}
+	children := func(name string) []string {
+		if _, ok := fetched[name]; !ok {
+			fetched[name] = membersOf(name)
+		}
+		return fetched[name]
+	}
+
+	visited := map[string]bool{}
+	var walk func(trail []string) []string
+	walk = func(trail []string) []string {
+		for _, child := range children(trail[len(trail)-1]) {
+			if strings.Contains(child, ":") {
+				continue
+			}
+			if child == group {
+				return append(trail, child)
+			}
+			if visited[child] {
+				continue
+			}
+			visited[child] = true
+			if cycle := walk(append(trail[:len(trail):len(trail)], child)); cycle != nil {
+				return cycle
+			}
+		}
+		return nil
+	}
+	return walk([]string{group})
 }
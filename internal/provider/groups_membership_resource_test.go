@@ -0,0 +1,254 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestGroupsMembershipResourceSchema(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	schemaResponse := &fwresource.SchemaResponse{}
+	NewGroupsMembershipResource().Schema(ctx, fwresource.SchemaRequest{}, schemaResponse)
+
+	if schemaResponse.Diagnostics.HasError() {
+		t.Fatalf("Schema method diagnostics: %+v", schemaResponse.Diagnostics)
+	}
+	if diagnostics := schemaResponse.Schema.ValidateImplementation(ctx); diagnostics.HasError() {
+		t.Fatalf("Schema validation diagnostics: %+v", diagnostics)
+	}
+}
+
+func TestDiffMembers(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		want        []string
+		current     []string
+		wantAdded   []string
+		wantRemoved []string
+	}{
+		{"unchanged", []string{"a", "b"}, []string{"b", "a"}, nil, nil},
+		{"added", []string{"a", "b"}, []string{"a"}, []string{"b"}, nil},
+		{"removed outside terraform", []string{"a"}, []string{"a", "c"}, nil, []string{"c"}},
+		{"replaced", []string{"a", "b"}, []string{"a", "c"}, []string{"b"}, []string{"c"}},
+		{"duplicate in config", []string{"a", "a"}, nil, []string{"a"}, nil},
+		{"emptied", nil, []string{"a"}, nil, []string{"a"}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			added, removed := diffMembers(tt.want, tt.current)
+			if !reflect.DeepEqual(added, tt.wantAdded) || !reflect.DeepEqual(removed, tt.wantRemoved) {
+				t.Errorf("diffMembers() = %v, %v, want %v, %v", added, removed, tt.wantAdded, tt.wantRemoved)
+			}
+		})
+	}
+}
+
+func TestRefreshMembers(t *testing.T) {
+	t.Parallel()
+
+	members := func(names ...string) []Member {
+		result := []Member{}
+		for _, name := range names {
+			result = append(result, Member{Name: types.StringValue(name)})
+		}
+		return result
+	}
+
+	tests := []struct {
+		name    string
+		prior   []Member
+		current []string
+		want    []Member
+	}{
+		{"keeps configured order", members("b", "a"), []string{"a", "b"}, members("b", "a")},
+		{"drops removed members", members("a", "b"), []string{"b"}, members("b")},
+		{"appends members added outside terraform", members("a"), []string{"workload:PIM_TEST:engine:app", "a"}, append(members("a"), parseMemberName("workload:PIM_TEST:engine:app"))},
+		{"matches typed members", []Member{{Type: types.StringValue("user"), Name: types.StringValue("hachandr")}}, []string{"user:hachandr"}, []Member{{Type: types.StringValue("user"), Name: types.StringValue("hachandr")}}},
+		{"empty group", members("a"), nil, members()},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := refreshMembers(tt.prior, tt.current); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("refreshMembers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindMembershipCycle(t *testing.T) {
+	t.Parallel()
+
+	graph := map[string][]string{
+		"PIM_READERS": {"PIM_OWNERS", "user:hachandr"},
+		"PIM_OWNERS":  {"PIM_ADMIN", "workload:PIM_TEST:engine:app"},
+		"PIM_ADMIN":   {"user:root"},
+		"LOOP_A":      {"LOOP_B"},
+		"LOOP_B":      {"LOOP_A"},
+	}
+	membersOf := func(group string) []string {
+		return graph[group]
+	}
+
+	tests := []struct {
+		name    string
+		group   string
+		members []string
+		want    []string
+	}{
+		{"no nested groups", "PIM_ADMIN", []string{"user:root", "machine:PIM_TEST:host1"}, nil},
+		{"nested without cycle", "PIM_ADMIN", []string{"PIM_NEW"}, nil},
+		{"self membership", "PIM_ADMIN", []string{"PIM_ADMIN"}, []string{"PIM_ADMIN", "PIM_ADMIN"}},
+		{"indirect cycle", "PIM_ADMIN", []string{"user:root", "PIM_READERS"}, []string{"PIM_ADMIN", "PIM_READERS", "PIM_OWNERS", "PIM_ADMIN"}},
+		{"member containing the group", "PIM_OWNERS", []string{"PIM_READERS"}, []string{"PIM_OWNERS", "PIM_READERS", "PIM_OWNERS"}},
+		{"existing cycle elsewhere", "PIM_ADMIN", []string{"LOOP_A"}, nil},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := findMembershipCycle(tt.group, tt.members, membersOf); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("findMembershipCycle() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGroupsMembershipResourceDelete(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	var deleted []string
+	r := &groupsMembershipResource{
+		client: testKMIClient(t, http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.Method == http.MethodDelete {
+				deleted = append(deleted, req.URL.Path)
+			}
+			w.WriteHeader(http.StatusNoContent)
+		})),
+	}
+	schemaResponse := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResponse)
+	state := tfsdk.State{
+		Schema: schemaResponse.Schema,
+		Raw:    tftypes.NewValue(schemaResponse.Schema.Type().TerraformType(ctx), nil),
+	}
+	diags := state.Set(ctx, &groupsMembershipModel{
+		GroupName:   types.StringValue("PIM_ADMIN"),
+		LastUpdated: types.StringNull(),
+		Members: []Member{
+			testMember("user", map[string]string{"name": "bob"}),
+			testMember("workload", map[string]string{"account": "PIM_TEST", "engine": "engine", "projection": "app"}),
+			testMember("machine", map[string]string{"account": "PIM_TEST", "name": "host1"}),
+			testMember("", map[string]string{"name": "PIM_OWNERS"}),
+		},
+	})
+	if diags.HasError() {
+		t.Fatalf("State.Set() diagnostics = %v", diags)
+	}
+
+	resp := &fwresource.DeleteResponse{State: state}
+	r.Delete(ctx, fwresource.DeleteRequest{State: state}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Delete() diagnostics = %v", resp.Diagnostics)
+	}
+
+	want := []string{
+		"/group_membership/Parent=PIM_ADMIN/Child=user:bob",
+		"/group_membership/Parent=PIM_ADMIN/Child=workload:PIM_TEST:engine:app",
+		"/group_membership/Parent=PIM_ADMIN/Child=machine:PIM_TEST:host1",
+		"/group_membership/Parent=PIM_ADMIN/Child=PIM_OWNERS",
+	}
+	if !reflect.DeepEqual(deleted, want) {
+		t.Errorf("Delete() removed %v, want %v", deleted, want)
+	}
+}
+
+func TestGroupsMembershipResourceModifyPlan(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	r := &groupsMembershipResource{
+		client: testKMIClient(t, http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			switch req.URL.Path {
+			case "/group_membership/Parent=PIM_OWNERS":
+				fmt.Fprint(w, `<group_memberships><group_membership parent="PIM_OWNERS" child="PIM_ADMIN"/></group_memberships>`)
+			case "/group_membership/Parent=PIM_BROKEN":
+				w.WriteHeader(http.StatusInternalServerError)
+			case "/group/Name=PIM_BROKEN":
+				fmt.Fprint(w, `<group name="PIM_BROKEN"/>`)
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		})),
+	}
+	schemaResponse := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResponse)
+
+	tests := []struct {
+		name        string
+		members     []string
+		wantError   bool
+		wantWarning bool
+	}{
+		{"new group", []string{"PIM_NEW", "user:bob"}, false, false},
+		{"cycle", []string{"PIM_OWNERS"}, true, false},
+		{"unreadable group", []string{"PIM_BROKEN"}, false, true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			members := []Member{}
+			for _, name := range tt.members {
+				members = append(members, parseMemberName(name))
+			}
+			plan := tfsdk.Plan{
+				Schema: schemaResponse.Schema,
+				Raw:    tftypes.NewValue(schemaResponse.Schema.Type().TerraformType(ctx), nil),
+			}
+			diags := plan.Set(ctx, &groupsMembershipModel{
+				GroupName:   types.StringValue("PIM_ADMIN"),
+				LastUpdated: types.StringUnknown(),
+				Members:     members,
+			})
+			if diags.HasError() {
+				t.Fatalf("Plan.Set() diagnostics = %v", diags)
+			}
+
+			resp := &fwresource.ModifyPlanResponse{Plan: plan}
+			r.ModifyPlan(ctx, fwresource.ModifyPlanRequest{Plan: plan}, resp)
+
+			if resp.Diagnostics.HasError() != tt.wantError || (resp.Diagnostics.WarningsCount() == 1) != tt.wantWarning {
+				t.Errorf("ModifyPlan() diagnostics = %v, want error %v, warning %v", resp.Diagnostics, tt.wantError, tt.wantWarning)
+			}
+			if tt.wantWarning && !strings.Contains(resp.Diagnostics.Warnings()[0].Detail(), "PIM_BROKEN") {
+				t.Errorf("ModifyPlan() warning = %v, want it to name PIM_BROKEN", resp.Diagnostics.Warnings())
+			}
+		})
+	}
+}
@@ -6,12 +6,19 @@ import (
 	"terraform-provider-kmi/internal/kmi"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
+// groupTypes are the ways KMI combines the members of a group.
+var groupTypes = []string{"union", "intersection"}
+
 // Ensure the implementation satisfies the expected interfaces.
 var (
 	_ resource.Resource              = &groupsResource{}
@@ -40,23 +47,57 @@ func (r *groupsResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 			"group_name": schema.StringAttribute{
 				Required:    true,
 				Description: "The name of the group to create. ",
+				Validators: []validator.String{
+					kmiNameValidator(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 
 			"account_name": schema.StringAttribute{
 				Required:    true,
 				Description: "The name of the account that KMI has been enabled for. ",
+				Validators: []validator.String{
+					kmiNameValidator(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"type": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "How KMI combines the members of the group, either \"union\" or \"intersection\". Defaults to \"union\". ",
+				Validators: []validator.String{
+					stringOneOf(groupTypes...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"adders": schema.StringAttribute{
+				Optional:    true,
 				Computed:    true,
-				Description: "The list of adders for the group. ",
+				Description: "The group allowed to add members to the group. KMI assigns its default when unset. ",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"modifiers": schema.StringAttribute{
+				Optional:    true,
 				Computed:    true,
-				Description: "The list of modifiers for the group. ",
+				Description: "The group allowed to modify the group. KMI assigns its default when unset. ",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"last_updated": schema.StringAttribute{
 				Computed:    true,
 				Description: "The last time the group was updated. ",
+				PlanModifiers: []planmodifier.String{
+					useStateForUnknownUnlessChanged(path.Root("type"), path.Root("adders"), path.Root("modifiers")),
+				},
 			},
 		},
 	}
@@ -72,7 +113,7 @@ func (r *groupsResource) Create(ctx context.Context, req resource.CreateRequest,
 	}
 	tflog.Info(ctx, "Create Groups Request")
 
-	err := r.client.CreateGroup(plan.AccountName.ValueString(), plan.GroupName.ValueString())
+	err := r.client.CreateGroup(plan.AccountName.ValueString(), plan.GroupName.ValueString(), plan.groupRequest())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating Group",
@@ -92,8 +133,7 @@ func (r *groupsResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	plan.Adders = types.StringValue(groupInfo.Adders)
-	plan.Modifiers = types.StringValue(groupInfo.Modifiers)
+	plan.refresh(groupInfo)
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
@@ -123,8 +163,7 @@ func (r *groupsResource) Read(ctx context.Context, req resource.ReadRequest, res
 	}
 
 	state.AccountName = types.StringValue(groupInfo.Account)
-	state.Adders = types.StringValue(groupInfo.Adders)
-	state.Modifiers = types.StringValue(groupInfo.Modifiers)
+	state.refresh(groupInfo)
 
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -143,7 +182,7 @@ func (r *groupsResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	err := r.client.CreateGroup(plan.AccountName.ValueString(), plan.GroupName.ValueString())
+	err := r.client.CreateGroup(plan.AccountName.ValueString(), plan.GroupName.ValueString(), plan.groupRequest())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating Group",
@@ -152,7 +191,11 @@ func (r *groupsResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+	// KMI keeps no modified timestamp, so last_updated only moves when the plan
+	// changes the group.
+	if plan.LastUpdated.IsUnknown() {
+		plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+	}
 
 	groupInfo, err := r.client.GetGroup(plan.GroupName.ValueString())
 	if err != nil {
@@ -163,8 +206,7 @@ func (r *groupsResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	plan.Adders = types.StringValue(groupInfo.Adders)
-	plan.Modifiers = types.StringValue(groupInfo.Modifiers)
+	plan.refresh(groupInfo)
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
@@ -216,7 +258,25 @@ func (r *groupsResource) Configure(_ context.Context, req resource.ConfigureRequ
 type groupResourceModel struct {
 	AccountName types.String `tfsdk:"account_name"`
 	GroupName   types.String `tfsdk:"group_name"`
+	Type        types.String `tfsdk:"type"`
 	Adders      types.String `tfsdk:"adders"`
 	Modifiers   types.String `tfsdk:"modifiers"`
 	LastUpdated types.String `tfsdk:"last_updated"`
 }
+
+// groupRequest returns the KMI request for the group. Unset adders and
+// modifiers are left for KMI to default.
+func (m groupResourceModel) groupRequest() kmi.GroupRequest {
+	return kmi.GroupRequest{
+		Type:      m.Type.ValueString(),
+		Adders:    m.Adders.ValueString(),
+		Modifiers: m.Modifiers.ValueString(),
+	}
+}
+
+// refresh copies the type and ACLs KMI reports for the group into the model.
+func (m *groupResourceModel) refresh(group *kmi.KMIGroup) {
+	m.Type = types.StringValue(group.Type)
+	m.Adders = types.StringValue(group.Adders)
+	m.Modifiers = types.StringValue(group.Modifiers)
+}
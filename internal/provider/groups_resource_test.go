@@ -2,9 +2,11 @@ package provider
 
 import (
 	"context"
+	"terraform-provider-kmi/internal/kmi"
 	"testing"
 
 	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 func TestGroupResourceSchema(t *testing.T) {
@@ -28,3 +30,58 @@ func TestGroupResourceSchema(t *testing.T) {
 		t.Fatalf("Schema validation diagnostics: %+v", diagnostics)
 	}
 }
+
+func TestGroupRequest(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		model groupResourceModel
+		want  kmi.GroupRequest
+	}{
+		{
+			name: "configured",
+			model: groupResourceModel{
+				Type:      types.StringValue("intersection"),
+				Adders:    types.StringValue("superusers"),
+				Modifiers: types.StringValue("PIM_OWNERS"),
+			},
+			want: kmi.GroupRequest{Type: "intersection", Adders: "superusers", Modifiers: "PIM_OWNERS"},
+		},
+		{
+			name: "left for KMI to default",
+			model: groupResourceModel{
+				Type:      types.StringUnknown(),
+				Adders:    types.StringUnknown(),
+				Modifiers: types.StringNull(),
+			},
+			want: kmi.GroupRequest{},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.model.groupRequest(); got != tt.want {
+				t.Errorf("groupRequest() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGroupRefresh(t *testing.T) {
+	t.Parallel()
+
+	model := groupResourceModel{
+		Type:      types.StringValue("union"),
+		Adders:    types.StringValue("superusers"),
+		Modifiers: types.StringValue("superusers"),
+	}
+	model.refresh(&kmi.KMIGroup{Type: "intersection", Adders: "PIM_ADMIN", Modifiers: "PIM_OWNERS"})
+
+	if model.Type.ValueString() != "intersection" || model.Adders.ValueString() != "PIM_ADMIN" || model.Modifiers.ValueString() != "PIM_OWNERS" {
+		t.Errorf("refresh() = %+v, want the values KMI reports", model)
+	}
+}
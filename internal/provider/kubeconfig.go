@@ -0,0 +1,144 @@
+package provider
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// kubeconfig models the subset of a Kubernetes client configuration file
+// needed to register a cluster as a KMI identity engine.
+type kubeconfig struct {
+	CurrentContext string `yaml:"current-context"`
+	Clusters       []struct {
+		Name    string `yaml:"name"`
+		Cluster struct {
+			Server                   string `yaml:"server"`
+			CertificateAuthority     string `yaml:"certificate-authority"`
+			CertificateAuthorityData string `yaml:"certificate-authority-data"`
+		} `yaml:"cluster"`
+	} `yaml:"clusters"`
+	Contexts []struct {
+		Name    string `yaml:"name"`
+		Context struct {
+			Cluster string `yaml:"cluster"`
+		} `yaml:"context"`
+	} `yaml:"contexts"`
+}
+
+// kubeconfigCluster returns the API endpoint and base64 encoded CA bundle of the
+// cluster referenced by kubeContext. When kubeContext is empty the kubeconfig's
+// current-context is used. kubeconfigValue is either the raw YAML content or a
+// path to a kubeconfig file.
+func kubeconfigCluster(kubeconfigValue string, kubeContext string) (string, string, error) {
+	content := []byte(kubeconfigValue)
+	// Relative certificate-authority paths are resolved against the directory of
+	// the kubeconfig file, as kubectl does.
+	baseDir := ""
+	if !isKubeconfigContent(kubeconfigValue) {
+		data, err := os.ReadFile(kubeconfigValue)
+		if err != nil {
+			return "", "", fmt.Errorf("could not read kubeconfig file %s: %w", kubeconfigValue, err)
+		}
+		content = data
+		baseDir = filepath.Dir(kubeconfigValue)
+	}
+
+	var config kubeconfig
+	if err := yaml.Unmarshal(content, &config); err != nil {
+		return "", "", fmt.Errorf("could not parse kubeconfig: %w", err)
+	}
+
+	if kubeContext == "" {
+		kubeContext = config.CurrentContext
+	}
+	if kubeContext == "" {
+		return "", "", fmt.Errorf("kubeconfig has no current-context, kubeconfig_context must be set")
+	}
+
+	clusterName := ""
+	for _, c := range config.Contexts {
+		if c.Name == kubeContext {
+			clusterName = c.Context.Cluster
+			break
+		}
+	}
+	if clusterName == "" {
+		return "", "", fmt.Errorf("context %s not found in kubeconfig", kubeContext)
+	}
+
+	for _, c := range config.Clusters {
+		if c.Name != clusterName {
+			continue
+		}
+		if c.Cluster.Server == "" {
+			return "", "", fmt.Errorf("cluster %s has no server in kubeconfig", clusterName)
+		}
+		casBase64 := c.Cluster.CertificateAuthorityData
+		if casBase64 == "" && c.Cluster.CertificateAuthority != "" {
+			caPath := c.Cluster.CertificateAuthority
+			if baseDir != "" && !filepath.IsAbs(caPath) {
+				caPath = filepath.Join(baseDir, caPath)
+			}
+			caPem, err := os.ReadFile(caPath)
+			if err != nil {
+				return "", "", fmt.Errorf("could not read certificate-authority %s: %w", caPath, err)
+			}
+			casBase64 = base64.StdEncoding.EncodeToString(caPem)
+		}
+		if casBase64 == "" {
+			return "", "", fmt.Errorf("cluster %s has no certificate authority in kubeconfig", clusterName)
+		}
+		return c.Cluster.Server, casBase64, nil
+	}
+	return "", "", fmt.Errorf("cluster %s not found in kubeconfig", clusterName)
+}
+
+// isKubeconfigContent reports whether value holds kubeconfig content rather
+// than the path of a kubeconfig file. Content is multi-line YAML, single-line
+// JSON or a single YAML line starting with apiVersion.
+func isKubeconfigContent(value string) bool {
+	trimmed := strings.TrimSpace(value)
+	return strings.Contains(trimmed, "\n") ||
+		strings.HasPrefix(trimmed, "{") ||
+		strings.HasPrefix(trimmed, "apiVersion:")
+}
+
+// validateCABundle checks that casBase64 is a base64 encoded PEM bundle made up of
+// one or more parseable x509 certificates.
+func validateCABundle(casBase64 string) error {
+	caPem, err := base64.StdEncoding.DecodeString(strings.TrimSpace(casBase64))
+	if err != nil {
+		return fmt.Errorf("cas_base64 is not valid base64: %w", err)
+	}
+
+	count := 0
+	rest := caPem
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			return fmt.Errorf("cas_base64 contains an unexpected %s PEM block", block.Type)
+		}
+		if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+			return fmt.Errorf("cas_base64 contains an invalid certificate: %w", err)
+		}
+		count++
+	}
+	if count == 0 {
+		return fmt.Errorf("cas_base64 does not contain any PEM certificates")
+	}
+	if len(strings.TrimSpace(string(rest))) != 0 {
+		return fmt.Errorf("cas_base64 contains trailing data after the last PEM certificate")
+	}
+	return nil
+}
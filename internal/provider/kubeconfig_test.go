@@ -0,0 +1,156 @@
+package provider
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testCAPem(t *testing.T) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "kubernetes"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func testKubeconfig(casBase64 string) string {
+	return fmt.Sprintf(`apiVersion: v1
+kind: Config
+current-context: prod
+clusters:
+- name: prod-cluster
+  cluster:
+    server: https://prod.example.com:443
+    certificate-authority-data: %s
+- name: dev-cluster
+  cluster:
+    server: https://dev.example.com:443
+    certificate-authority-data: %s
+contexts:
+- name: prod
+  context:
+    cluster: prod-cluster
+    user: admin
+- name: dev
+  context:
+    cluster: dev-cluster
+    user: admin
+`, casBase64, casBase64)
+}
+
+func TestKubeconfigCluster(t *testing.T) {
+	casBase64 := base64.StdEncoding.EncodeToString(testCAPem(t))
+	content := testKubeconfig(casBase64)
+
+	endpoint, cas, err := kubeconfigCluster(content, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://prod.example.com:443", endpoint)
+	assert.Equal(t, casBase64, cas)
+
+	endpoint, _, err = kubeconfigCluster(content, "dev")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://dev.example.com:443", endpoint)
+
+	_, _, err = kubeconfigCluster(content, "missing")
+	assert.Error(t, err)
+}
+
+func TestKubeconfigClusterFromPath(t *testing.T) {
+	casBase64 := base64.StdEncoding.EncodeToString(testCAPem(t))
+	kubeconfigPath := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(kubeconfigPath, []byte(testKubeconfig(casBase64)), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	endpoint, cas, err := kubeconfigCluster(kubeconfigPath, "prod")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://prod.example.com:443", endpoint)
+	assert.Equal(t, casBase64, cas)
+}
+
+func TestKubeconfigClusterSingleLineJSON(t *testing.T) {
+	casBase64 := base64.StdEncoding.EncodeToString(testCAPem(t))
+	content := fmt.Sprintf(`{"apiVersion":"v1","kind":"Config","current-context":"prod","clusters":[{"name":"prod-cluster","cluster":{"server":"https://prod.example.com:443","certificate-authority-data":%q}}],"contexts":[{"name":"prod","context":{"cluster":"prod-cluster"}}]}`, casBase64)
+
+	endpoint, cas, err := kubeconfigCluster(content, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://prod.example.com:443", endpoint)
+	assert.Equal(t, casBase64, cas)
+}
+
+func TestKubeconfigClusterRelativeCertificateAuthority(t *testing.T) {
+	caPem := testCAPem(t)
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "certs"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "certs", "ca.crt"), caPem, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	kubeconfigPath := filepath.Join(dir, "config")
+	content := `apiVersion: v1
+current-context: prod
+clusters:
+- name: prod-cluster
+  cluster:
+    server: https://prod.example.com:443
+    certificate-authority: certs/ca.crt
+contexts:
+- name: prod
+  context:
+    cluster: prod-cluster
+`
+	if err := os.WriteFile(kubeconfigPath, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, cas, err := kubeconfigCluster(kubeconfigPath, "")
+	assert.NoError(t, err)
+	assert.Equal(t, base64.StdEncoding.EncodeToString(caPem), cas)
+}
+
+func TestIsKubeconfigContent(t *testing.T) {
+	assert.True(t, isKubeconfigContent("apiVersion: v1\nkind: Config\n"))
+	assert.True(t, isKubeconfigContent(`{"apiVersion":"v1"}`))
+	assert.True(t, isKubeconfigContent("apiVersion: v1"))
+	assert.False(t, isKubeconfigContent("~/.kube/config"))
+	assert.False(t, isKubeconfigContent("/etc/kubernetes/admin.conf"))
+}
+
+func TestValidateCABundle(t *testing.T) {
+	caPem := testCAPem(t)
+	chain := append(append([]byte{}, caPem...), testCAPem(t)...)
+
+	assert.NoError(t, validateCABundle(base64.StdEncoding.EncodeToString(caPem)))
+	assert.NoError(t, validateCABundle(base64.StdEncoding.EncodeToString(chain)))
+	assert.Error(t, validateCABundle("not base64!"))
+	assert.Error(t, validateCABundle(base64.StdEncoding.EncodeToString([]byte("not a certificate"))))
+	assert.Error(t, validateCABundle(base64.StdEncoding.EncodeToString(
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("garbage")}),
+	)))
+}
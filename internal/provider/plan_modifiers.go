@@ -0,0 +1,128 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+const definitionTypeChangedDescription = "Changing the definition type requires the definition to be replaced."
+
+// requiresReplaceIfDefinitionTypeObject replaces the resource when a definition
+// type block is added or removed, while changes inside the block are updated in place.
+func requiresReplaceIfDefinitionTypeObject() planmodifier.Object {
+	return objectplanmodifier.RequiresReplaceIf(
+		func(_ context.Context, req planmodifier.ObjectRequest, resp *objectplanmodifier.RequiresReplaceIfFuncResponse) {
+			resp.RequiresReplace = req.StateValue.IsNull() != req.PlanValue.IsNull()
+		},
+		definitionTypeChangedDescription,
+		definitionTypeChangedDescription,
+	)
+}
+
+// requiresReplaceIfDefinitionTypeString is the string attribute counterpart of
+// requiresReplaceIfDefinitionTypeObject, used by opaque and transparent definitions.
+func requiresReplaceIfDefinitionTypeString() planmodifier.String {
+	return stringplanmodifier.RequiresReplaceIf(
+		func(_ context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+			resp.RequiresReplace = req.StateValue.IsNull() != req.PlanValue.IsNull()
+		},
+		definitionTypeChangedDescription,
+		definitionTypeChangedDescription,
+	)
+}
+
+const groupMemberChangedDescription = "Changing the KMI name of the member requires the membership to be replaced."
+
+// requiresReplaceIfMemberChanged replaces a group membership when the member
+// it names changes, while spelling the same member differently, such as
+// name = "user:bob" instead of type = "user" and name = "bob", is updated in place.
+func requiresReplaceIfMemberChanged() planmodifier.Object {
+	return objectplanmodifier.RequiresReplaceIf(
+		func(ctx context.Context, req planmodifier.ObjectRequest, resp *objectplanmodifier.RequiresReplaceIfFuncResponse) {
+			var prior, planned Member
+			resp.Diagnostics.Append(req.StateValue.As(ctx, &prior, basetypes.ObjectAsOptions{})...)
+			resp.Diagnostics.Append(req.PlanValue.As(ctx, &planned, basetypes.ObjectAsOptions{})...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			priorName, _ := prior.childName()
+			plannedName, known := planned.childName()
+			resp.RequiresReplace = !known || priorName != plannedName
+		},
+		groupMemberChangedDescription,
+		groupMemberChangedDescription,
+	)
+}
+
+var (
+	_ planmodifier.String = useStateForUnknownUnlessChangedModifier{}
+	_ planmodifier.List   = useStateForUnknownUnlessChangedModifier{}
+	_ planmodifier.Map    = useStateForUnknownUnlessChangedModifier{}
+)
+
+// useStateForUnknownUnlessChanged keeps the prior state value of a computed
+// attribute in the plan unless one of the attributes it is derived from changes.
+func useStateForUnknownUnlessChanged(paths ...path.Path) useStateForUnknownUnlessChangedModifier {
+	return useStateForUnknownUnlessChangedModifier{paths: paths}
+}
+
+type useStateForUnknownUnlessChangedModifier struct {
+	paths []path.Path
+}
+
+func (m useStateForUnknownUnlessChangedModifier) Description(_ context.Context) string {
+	return "Once set, the value of this attribute in state will not change unless the attributes it depends on change."
+}
+
+func (m useStateForUnknownUnlessChangedModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m useStateForUnknownUnlessChangedModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if req.StateValue.IsNull() || !req.PlanValue.IsUnknown() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if m.unchanged(ctx, req.Plan, req.State, &resp.Diagnostics) {
+		resp.PlanValue = req.StateValue
+	}
+}
+
+func (m useStateForUnknownUnlessChangedModifier) PlanModifyList(ctx context.Context, req planmodifier.ListRequest, resp *planmodifier.ListResponse) {
+	if req.StateValue.IsNull() || !req.PlanValue.IsUnknown() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if m.unchanged(ctx, req.Plan, req.State, &resp.Diagnostics) {
+		resp.PlanValue = req.StateValue
+	}
+}
+
+func (m useStateForUnknownUnlessChangedModifier) PlanModifyMap(ctx context.Context, req planmodifier.MapRequest, resp *planmodifier.MapResponse) {
+	if req.StateValue.IsNull() || !req.PlanValue.IsUnknown() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if m.unchanged(ctx, req.Plan, req.State, &resp.Diagnostics) {
+		resp.PlanValue = req.StateValue
+	}
+}
+
+// unchanged reports whether all the dependent attributes have the same value in
+// the plan as in the prior state.
+func (m useStateForUnknownUnlessChangedModifier) unchanged(ctx context.Context, plan tfsdk.Plan, state tfsdk.State, diags *diag.Diagnostics) bool {
+	for _, p := range m.paths {
+		var planValue, stateValue attr.Value
+		diags.Append(plan.GetAttribute(ctx, p, &planValue)...)
+		diags.Append(state.GetAttribute(ctx, p, &stateValue)...)
+		if diags.HasError() || !planValue.Equal(stateValue) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,163 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestRequiresReplaceIfDefinitionTypeString(t *testing.T) {
+	t.Parallel()
+
+	existing := tftypes.NewValue(tftypes.Object{}, map[string]tftypes.Value{})
+
+	tests := []struct {
+		name        string
+		state       types.String
+		plan        types.String
+		wantReplace bool
+	}{
+		{"content changed", types.StringValue("a"), types.StringValue("b"), false},
+		{"type removed", types.StringValue("a"), types.StringNull(), true},
+		{"type added", types.StringNull(), types.StringValue("b"), true},
+		{"unchanged", types.StringValue("a"), types.StringValue("a"), false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := planmodifier.StringRequest{
+				State:      tfsdk.State{Raw: existing},
+				Plan:       tfsdk.Plan{Raw: existing},
+				StateValue: tt.state,
+				PlanValue:  tt.plan,
+			}
+			resp := &planmodifier.StringResponse{PlanValue: tt.plan}
+			requiresReplaceIfDefinitionTypeString().PlanModifyString(context.Background(), req, resp)
+
+			if resp.RequiresReplace != tt.wantReplace {
+				t.Errorf("RequiresReplace = %v, want %v", resp.RequiresReplace, tt.wantReplace)
+			}
+		})
+	}
+}
+
+func TestRequiresReplaceIfMemberChanged(t *testing.T) {
+	t.Parallel()
+
+	existing := tftypes.NewValue(tftypes.Object{}, map[string]tftypes.Value{})
+	attrTypes := map[string]attr.Type{
+		"type":       types.StringType,
+		"name":       types.StringType,
+		"account":    types.StringType,
+		"engine":     types.StringType,
+		"projection": types.StringType,
+	}
+	object := func(member Member) types.Object {
+		value, diags := types.ObjectValueFrom(context.Background(), attrTypes, member)
+		if diags.HasError() {
+			t.Fatalf("ObjectValueFrom() diagnostics = %v", diags)
+		}
+		return value
+	}
+
+	tests := []struct {
+		name        string
+		state       Member
+		plan        Member
+		wantReplace bool
+	}{
+		{"typed group imported untyped", testMember("", map[string]string{"name": "PIM_OWNERS"}), testMember("group", map[string]string{"name": "PIM_OWNERS"}), false},
+		{"user spelled out", testMember("", map[string]string{"name": "user:bob"}), testMember("user", map[string]string{"name": "bob"}), false},
+		{"other user", testMember("user", map[string]string{"name": "bob"}), testMember("user", map[string]string{"name": "alice"}), true},
+		{"group became user", testMember("", map[string]string{"name": "bob"}), testMember("user", map[string]string{"name": "bob"}), true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := planmodifier.ObjectRequest{
+				State:      tfsdk.State{Raw: existing},
+				Plan:       tfsdk.Plan{Raw: existing},
+				StateValue: object(tt.state),
+				PlanValue:  object(tt.plan),
+			}
+			resp := &planmodifier.ObjectResponse{PlanValue: req.PlanValue}
+			requiresReplaceIfMemberChanged().PlanModifyObject(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("PlanModifyObject() diagnostics = %v", resp.Diagnostics)
+			}
+			if resp.RequiresReplace != tt.wantReplace {
+				t.Errorf("RequiresReplace = %v, want %v", resp.RequiresReplace, tt.wantReplace)
+			}
+		})
+	}
+}
+
+func TestUseStateForUnknownUnlessChanged(t *testing.T) {
+	t.Parallel()
+
+	objectType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"kubeconfig":   tftypes.String,
+		"api_endpoint": tftypes.String,
+	}}
+	testSchema := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"kubeconfig":   schema.StringAttribute{Optional: true},
+			"api_endpoint": schema.StringAttribute{Computed: true},
+		},
+	}
+	value := func(kubeconfig string, endpoint tftypes.Value) tftypes.Value {
+		return tftypes.NewValue(objectType, map[string]tftypes.Value{
+			"kubeconfig":   tftypes.NewValue(tftypes.String, kubeconfig),
+			"api_endpoint": endpoint,
+		})
+	}
+	state := value("a", tftypes.NewValue(tftypes.String, "https://a"))
+
+	tests := []struct {
+		name       string
+		kubeconfig string
+		want       types.String
+	}{
+		{"dependency unchanged", "a", types.StringValue("https://a")},
+		{"dependency changed", "b", types.StringUnknown()},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := planmodifier.StringRequest{
+				Path:        path.Root("api_endpoint"),
+				State:       tfsdk.State{Schema: testSchema, Raw: state},
+				Plan:        tfsdk.Plan{Schema: testSchema, Raw: value(tt.kubeconfig, tftypes.NewValue(tftypes.String, tftypes.UnknownValue))},
+				StateValue:  types.StringValue("https://a"),
+				PlanValue:   types.StringUnknown(),
+				ConfigValue: types.StringNull(),
+			}
+			resp := &planmodifier.StringResponse{PlanValue: req.PlanValue}
+			useStateForUnknownUnlessChanged(path.Root("kubeconfig")).PlanModifyString(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("PlanModifyString() diagnostics = %v", resp.Diagnostics)
+			}
+			if !resp.PlanValue.Equal(tt.want) {
+				t.Errorf("PlanModifyString() = %v, want %v", resp.PlanValue, tt.want)
+			}
+		})
+	}
+}
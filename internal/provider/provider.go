@@ -301,6 +301,7 @@ func (p *kmiProvider) DataSources(_ context.Context) []func() datasource.DataSou
 	return []func() datasource.DataSource{
 		NewAccountDataSource,
 		NewCollectionsDataSource,
+		NewTemplateCheckDataSource,
 	}
 }
 
@@ -312,8 +313,18 @@ func (p *kmiProvider) Resources(_ context.Context) []func() resource.Resource {
 		NewCollectionsResource,
 		NewGroupsResource,
 		NewDefinitionsResource,
+		NewSSLCertDefinitionResource,
+		NewAzureSPDefinitionResource,
+		NewSymmetricKeyDefinitionResource,
+		NewAsymmetricKeyDefinitionResource,
+		NewSSHKeyDefinitionResource,
+		NewPasswordDefinitionResource,
+		NewOpaqueDefinitionResource,
+		NewTransparentDefinitionResource,
 		NewGroupsMembershipResource,
+		NewGroupMemberResource,
 		NewTemplateResource,
+		NewSignedCertificateResource,
 		NewWorkloadResource,
 	}
 }
@@ -3,6 +3,15 @@
 
 package provider
 
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"terraform-provider-kmi/internal/kmi"
+	"testing"
+	"time"
+)
+
 const (
 // providerConfig is a shared configuration to combine with the actual
 // test configuration so the KMI client is properly configured.
@@ -32,3 +41,18 @@ const (
 // 	// about the appropriate environment variables being set are common to see in a pre-check
 // 	// function.
 // }
+
+// testKMIClient returns a KMI client sending its requests to handler.
+func testKMIClient(t *testing.T, handler http.Handler) *kmi.KMIRestClient {
+	t.Helper()
+	svr := httptest.NewTLSServer(handler)
+	t.Cleanup(svr.Close)
+
+	cert, key := testCertificate(t, 1, time.Now().Add(time.Hour))
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: svr.Certificate().Raw})
+	client, err := kmi.NewKMIRestClient(svr.URL, key, cert, string(ca), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
@@ -0,0 +1,277 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-kmi/internal/kmi"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource               = &signedCertificateResource{}
+	_ resource.ResourceWithConfigure  = &signedCertificateResource{}
+	_ resource.ResourceWithModifyPlan = &signedCertificateResource{}
+)
+
+func NewSignedCertificateResource() resource.Resource {
+	return &signedCertificateResource{}
+}
+
+// signedCertificateResource signs a certificate signing request with a KMI CA
+// definition according to one of its templates.
+type signedCertificateResource struct {
+	client *kmi.KMIRestClient
+}
+
+// Metadata returns the resource type name.
+func (r *signedCertificateResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_signed_certificate"
+}
+
+// Schema defines the schema for the resource.
+func (r *signedCertificateResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Signs a certificate signing request with a KMI CA definition according to a template. The certificate is signed again once it is within renewal_window of its expiry.",
+		Attributes: map[string]schema.Attribute{
+			"ca_collection": schema.StringAttribute{
+				Required:    true,
+				Description: "Collection of the CA definition. ",
+				Validators: []validator.String{
+					kmiNameValidator(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"ca_definition": schema.StringAttribute{
+				Required:    true,
+				Description: "CA definition signing the certificate. ",
+				Validators: []validator.String{
+					kmiNameValidator(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"template_name": schema.StringAttribute{
+				Required:    true,
+				Description: "Template of the CA definition the request is checked against. ",
+				Validators: []validator.String{
+					kmiNameValidator(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"cert_request_pem": schema.StringAttribute{
+				Required:    true,
+				Description: "PEM encoded certificate signing request, such as the cert_request_pem of a tls_cert_request. ",
+				Validators: []validator.String{
+					certificateRequestValidator{},
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"ttl": schema.StringAttribute{
+				Optional:    true,
+				CustomType:  ttlType{},
+				Description: "Requested validity of the certificate, such as \"30d\". Defaults to the template TTL bounds. ",
+				Validators: []validator.String{
+					ttlValidator{},
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"renewal_window": schema.StringAttribute{
+				Optional:    true,
+				CustomType:  ttlType{},
+				Description: "Sign the request again once the certificate expires within this TTL, such as \"7d\". ",
+				Validators: []validator.String{
+					ttlValidator{},
+				},
+			},
+			"ready_for_renewal": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether the certificate has expired or is within renewal_window of its expiry. ",
+			},
+			"certificate_pem": schema.StringAttribute{
+				Computed:    true,
+				Description: "PEM encoded signed certificate chain, leaf certificate first. ",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"serial_number": schema.StringAttribute{
+				Computed:    true,
+				Description: "Serial number of the certificate in decimal. ",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"not_before": schema.StringAttribute{
+				Computed:    true,
+				Description: "Start of the validity period of the certificate in RFC3339 format. ",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"not_after": schema.StringAttribute{
+				Computed:    true,
+				Description: "End of the validity period of the certificate in RFC3339 format. ",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// ModifyPlan replaces the certificate once it is ready for renewal. Whether the
+// replacement is ready for renewal is only known once it is signed.
+func (r *signedCertificateResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+	var readyForRenewal types.Bool
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("ready_for_renewal"), &readyForRenewal)...)
+	if resp.Diagnostics.HasError() || !readyForRenewal.ValueBool() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("ready_for_renewal"), types.BoolUnknown())...)
+	resp.RequiresReplace = append(resp.RequiresReplace, path.Root("ready_for_renewal"))
+}
+
+// Create signs the certificate request and sets the initial Terraform state.
+func (r *signedCertificateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan signedCertificateResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	secret, err := r.client.SignCertificateRequest(plan.CACollectionName.ValueString(), plan.CADefinitionName.ValueString(), plan.TemplateName.ValueString(), kmi.SignRequest{
+		CSR: plan.CertRequestPEM.ValueString(),
+		TTL: plan.TTL.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error signing the request",
+			"Could not sign the certificate request, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	details, err := parseCertificateDetails(secret)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error signing the request",
+			"Could not parse the signed certificate, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	plan.CertificatePEM = details.CertificatePEM
+	plan.SerialNumber = details.SerialNumber
+	plan.NotBefore = details.NotBefore
+	plan.NotAfter = details.NotAfter
+	plan.ReadyForRenewal = types.BoolValue(plan.readyForRenewal(time.Now()))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes whether the certificate is ready for renewal. KMI does not
+// keep the signed certificate, so there is nothing else to refresh.
+func (r *signedCertificateResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state signedCertificateResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.ReadyForRenewal = types.BoolValue(state.readyForRenewal(time.Now()))
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update only changes renewal_window, every other argument replaces the certificate.
+func (r *signedCertificateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan signedCertificateResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ReadyForRenewal = types.BoolValue(plan.readyForRenewal(time.Now()))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete removes the certificate from the Terraform state. KMI does not keep
+// signed certificates, so there is nothing to delete.
+func (r *signedCertificateResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+}
+
+func (r *signedCertificateResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*kmi.KMIRestClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *KMIRestClient., got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+type signedCertificateResourceModel struct {
+	CACollectionName types.String `tfsdk:"ca_collection"`
+	CADefinitionName types.String `tfsdk:"ca_definition"`
+	TemplateName     types.String `tfsdk:"template_name"`
+	CertRequestPEM   types.String `tfsdk:"cert_request_pem"`
+	TTL              ttlValue     `tfsdk:"ttl"`
+	RenewalWindow    ttlValue     `tfsdk:"renewal_window"`
+	ReadyForRenewal  types.Bool   `tfsdk:"ready_for_renewal"`
+	CertificatePEM   types.String `tfsdk:"certificate_pem"`
+	SerialNumber     types.String `tfsdk:"serial_number"`
+	NotBefore        types.String `tfsdk:"not_before"`
+	NotAfter         types.String `tfsdk:"not_after"`
+}
+
+// readyForRenewal reports whether the certificate has expired at now or expires
+// within the renewal window.
+func (m signedCertificateResourceModel) readyForRenewal(now time.Time) bool {
+	notAfter, err := time.Parse(time.RFC3339, m.NotAfter.ValueString())
+	if err != nil {
+		return false
+	}
+	var window time.Duration
+	if !m.RenewalWindow.IsNull() && !m.RenewalWindow.IsUnknown() {
+		window, err = parseTTL(m.RenewalWindow.ValueString())
+		if err != nil {
+			return false
+		}
+	}
+	return !now.Add(window).Before(notAfter)
+}
@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestSignedCertificateResourceSchema(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	schemaRequest := fwresource.SchemaRequest{}
+	schemaResponse := &fwresource.SchemaResponse{}
+
+	NewSignedCertificateResource().Schema(ctx, schemaRequest, schemaResponse)
+
+	if schemaResponse.Diagnostics.HasError() {
+		t.Fatalf("Schema method diagnostics: %+v", schemaResponse.Diagnostics)
+	}
+
+	diagnostics := schemaResponse.Schema.ValidateImplementation(ctx)
+
+	if diagnostics.HasError() {
+		t.Fatalf("Schema validation diagnostics: %+v", diagnostics)
+	}
+}
+
+func TestSignedCertificateReadyForRenewal(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		notAfter string
+		window   ttlValue
+		want     bool
+	}{
+		{"valid without window", "2024-06-02T00:00:00Z", ttlNull(), false},
+		{"expired without window", "2024-05-31T00:00:00Z", ttlNull(), true},
+		{"outside window", "2024-07-01T00:00:00Z", ttlString("7d"), false},
+		{"within window", "2024-06-05T00:00:00Z", ttlString("7d"), true},
+		{"no certificate", "", ttlString("7d"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := signedCertificateResourceModel{NotAfter: types.StringValue(tt.notAfter), RenewalWindow: tt.window}
+			if got := m.readyForRenewal(now); got != tt.want {
+				t.Errorf("readyForRenewal() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSignedCertificateModifyPlan(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	r, ok := NewSignedCertificateResource().(fwresource.ResourceWithModifyPlan)
+	if !ok {
+		t.Fatal("resource does not implement ResourceWithModifyPlan")
+	}
+
+	for _, ready := range []bool{false, true} {
+		config := testResourceConfig(t, r, map[string]tftypes.Value{
+			"ready_for_renewal": tftypes.NewValue(tftypes.Bool, ready),
+		})
+		req := fwresource.ModifyPlanRequest{
+			State: tfsdk.State{Schema: config.Schema, Raw: config.Raw},
+			Plan:  tfsdk.Plan{Schema: config.Schema, Raw: config.Raw},
+		}
+		resp := &fwresource.ModifyPlanResponse{Plan: req.Plan}
+		r.ModifyPlan(ctx, req, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("ModifyPlan() diagnostics = %v", resp.Diagnostics)
+		}
+
+		if got := len(resp.RequiresReplace) > 0; got != ready {
+			t.Errorf("ModifyPlan() ready_for_renewal = %v, requires replace = %v", ready, resp.RequiresReplace)
+		}
+		var planned types.Bool
+		resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("ready_for_renewal"), &planned)...)
+		want := types.BoolValue(false)
+		if ready {
+			want = types.BoolUnknown()
+		}
+		if !planned.Equal(want) {
+			t.Errorf("ModifyPlan() planned ready_for_renewal = %v, want %v", planned, want)
+		}
+	}
+}
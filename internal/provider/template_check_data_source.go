@@ -0,0 +1,151 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-kmi/internal/kmi"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &templateCheckDataSource{}
+	_ datasource.DataSourceWithConfigure = &templateCheckDataSource{}
+)
+
+func NewTemplateCheckDataSource() datasource.DataSource {
+	return &templateCheckDataSource{}
+}
+
+// templateCheckDataSource evaluates a certificate signing request against the
+// constraints of a template without submitting it to KMI.
+type templateCheckDataSource struct {
+	client *kmi.KMIRestClient
+}
+
+// Metadata returns the data source type name.
+func (d *templateCheckDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_template_check"
+}
+
+// Schema defines the schema for the data source.
+func (d *templateCheckDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Checks a certificate signing request against the constraints of a template locally, listing the violations KMI would reject the request for.",
+		Attributes: map[string]schema.Attribute{
+			"ca_collection": schema.StringAttribute{
+				Required:    true,
+				Description: "Collection of the CA definition. ",
+				Validators: []validator.String{
+					kmiNameValidator(),
+				},
+			},
+			"ca_definition": schema.StringAttribute{
+				Required:    true,
+				Description: "CA definition of the template. ",
+				Validators: []validator.String{
+					kmiNameValidator(),
+				},
+			},
+			"template_name": schema.StringAttribute{
+				Required:    true,
+				Description: "Template the request is checked against. ",
+				Validators: []validator.String{
+					kmiNameValidator(),
+				},
+			},
+			"cert_request_pem": schema.StringAttribute{
+				Required:    true,
+				Description: "PEM encoded certificate signing request. ",
+				Validators: []validator.String{
+					certificateRequestValidator{},
+				},
+			},
+			"ttl": schema.StringAttribute{
+				Optional:    true,
+				CustomType:  ttlType{},
+				Description: "Requested validity of the certificate, checked against min_ttl and max_ttl. ",
+				Validators: []validator.String{
+					ttlValidator{},
+				},
+			},
+			"valid": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether the request satisfies every constraint of the template. ",
+			},
+			"violations": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The constraints of the template the request violates. ",
+			},
+		},
+	}
+}
+
+// Read evaluates the request against the template constraints.
+func (d *templateCheckDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state templateCheckDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	csr, err := parseCertificateRequest(state.CertRequestPEM.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error parsing certificate request",
+			"Could not parse cert_request_pem: "+err.Error(),
+		)
+		return
+	}
+	templateDetails, err := d.client.GetTemplate(state.CACollectionName.ValueString(), state.CADefinitionName.ValueString(), state.TemplateName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Template",
+			"Could not read Template "+state.TemplateName.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	violations := checkCertificateRequest(csr, templateDetails.Constraints, state.TTL.ValueString())
+	state.Valid = types.BoolValue(len(violations) == 0)
+	state.Violations, diags = types.ListValueFrom(ctx, types.StringType, violations)
+	resp.Diagnostics.Append(diags...)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *templateCheckDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*kmi.KMIRestClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *kmi.KMIRestClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+type templateCheckDataSourceModel struct {
+	CACollectionName types.String `tfsdk:"ca_collection"`
+	CADefinitionName types.String `tfsdk:"ca_definition"`
+	TemplateName     types.String `tfsdk:"template_name"`
+	CertRequestPEM   types.String `tfsdk:"cert_request_pem"`
+	TTL              ttlValue     `tfsdk:"ttl"`
+	Valid            types.Bool   `tfsdk:"valid"`
+	Violations       types.List   `tfsdk:"violations"`
+}
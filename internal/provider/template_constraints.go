@@ -0,0 +1,215 @@
+package provider
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+	"terraform-provider-kmi/internal/kmi"
+)
+
+const (
+	// defaultTemplateKeyTypes are the key types accepted by a template without a
+	// key_type constraint.
+	defaultTemplateKeyTypes = "rsa:2048,rsa:4096,ec:secp256r1"
+	// defaultTemplateMinTTL and defaultTemplateMaxTTL bound the validity of
+	// certificates signed by a template without TTL constraints.
+	defaultTemplateMinTTL = "7d"
+	defaultTemplateMaxTTL = "90d"
+)
+
+// ecCurveNames maps the Go names of elliptic curves onto the names KMI uses.
+var ecCurveNames = map[string]string{
+	"P-256": "secp256r1",
+	"P-384": "secp384r1",
+	"P-521": "secp521r1",
+}
+
+// hashTypes maps the signature algorithms of a CSR onto their KMI hash type.
+var hashTypes = map[x509.SignatureAlgorithm]string{
+	x509.SHA1WithRSA:      "sha1",
+	x509.SHA256WithRSA:    "sha256",
+	x509.SHA384WithRSA:    "sha384",
+	x509.SHA512WithRSA:    "sha512",
+	x509.SHA256WithRSAPSS: "sha256",
+	x509.SHA384WithRSAPSS: "sha384",
+	x509.SHA512WithRSAPSS: "sha512",
+	x509.ECDSAWithSHA1:    "sha1",
+	x509.ECDSAWithSHA256:  "sha256",
+	x509.ECDSAWithSHA384:  "sha384",
+	x509.ECDSAWithSHA512:  "sha512",
+}
+
+// parseCertificateRequest parses a PEM encoded certificate signing request.
+func parseCertificateRequest(csrPEM string) (*x509.CertificateRequest, error) {
+	block, _ := pem.Decode([]byte(strings.TrimSpace(csrPEM)))
+	if block == nil || (block.Type != "CERTIFICATE REQUEST" && block.Type != "NEW CERTIFICATE REQUEST") {
+		return nil, errors.New("no PEM encoded CERTIFICATE REQUEST found")
+	}
+	csr, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	if err := csr.CheckSignature(); err != nil {
+		return nil, err
+	}
+	return csr, nil
+}
+
+// checkCertificateRequest evaluates a CSR against the constraints of a template,
+// returning a description of every violation. ttl is the requested validity and
+// is only checked when set.
+func checkCertificateRequest(csr *x509.CertificateRequest, constraints []kmi.ConstraintTypeResponse, ttl string) []string {
+	values := map[string]string{}
+	for _, constraint := range constraints {
+		values[constraint.Type] = constraint.Text
+	}
+	violations := []string{}
+
+	if pattern, ok := values["common_name"]; ok && !globMatch(pattern, csr.Subject.CommonName) {
+		violations = append(violations, fmt.Sprintf("common_name %q does not match %q", csr.Subject.CommonName, pattern))
+	}
+
+	dnsPatterns := splitCommaList(values["dns_san"])
+	for _, name := range csr.DNSNames {
+		if !globMatchAny(dnsPatterns, name) {
+			violations = append(violations, fmt.Sprintf("dns_san %q is not allowed by %q", name, values["dns_san"]))
+		}
+	}
+	uriPatterns := splitCommaList(values["uri_san"])
+	for _, uri := range csr.URIs {
+		if !globMatchAny(uriPatterns, uri.String()) {
+			violations = append(violations, fmt.Sprintf("uri_san %q is not allowed by %q", uri.String(), values["uri_san"]))
+		}
+	}
+	ipRanges := splitCommaList(values["ip_san"])
+	for _, ip := range csr.IPAddresses {
+		if !ipInRanges(ipRanges, ip) {
+			violations = append(violations, fmt.Sprintf("ip_san %q is not allowed by %q", ip.String(), values["ip_san"]))
+		}
+	}
+
+	keyTypes, ok := values["key_type"]
+	if !ok {
+		keyTypes = defaultTemplateKeyTypes
+	}
+	keyType := certificateRequestKeyType(csr)
+	if !stringInList(splitCommaList(keyTypes), keyType) {
+		violations = append(violations, fmt.Sprintf("key_type %q is not allowed by %q", keyType, keyTypes))
+	}
+
+	if hashTypes, ok := values["hash_type"]; ok && csr.PublicKeyAlgorithm != x509.Ed25519 {
+		hashType := certificateRequestHashType(csr)
+		if !stringInList(splitCommaList(hashTypes), hashType) {
+			violations = append(violations, fmt.Sprintf("hash_type %q is not allowed by %q", hashType, hashTypes))
+		}
+	}
+
+	if ttl != "" {
+		violations = append(violations, checkTTLBounds(ttl, values)...)
+	}
+	return violations
+}
+
+// checkTTLBounds checks that ttl is within the min_ttl and max_ttl of the template.
+func checkTTLBounds(ttl string, values map[string]string) []string {
+	requested, err := parseTTL(ttl)
+	if err != nil {
+		return []string{err.Error()}
+	}
+	minTTL, ok := values["min_ttl"]
+	if !ok {
+		minTTL = defaultTemplateMinTTL
+	}
+	maxTTL, ok := values["max_ttl"]
+	if !ok {
+		maxTTL = defaultTemplateMaxTTL
+	}
+
+	var violations []string
+	if minimum, err := parseTTL(minTTL); err == nil && requested < minimum {
+		violations = append(violations, fmt.Sprintf("ttl %q is shorter than min_ttl %q", ttl, minTTL))
+	}
+	if maximum, err := parseTTL(maxTTL); err == nil && requested > maximum {
+		violations = append(violations, fmt.Sprintf("ttl %q is longer than max_ttl %q", ttl, maxTTL))
+	}
+	return violations
+}
+
+// certificateRequestKeyType returns the KMI key type of the CSR public key, such
+// as "rsa:2048", "ec:secp256r1" or "ed25519".
+func certificateRequestKeyType(csr *x509.CertificateRequest) string {
+	switch key := csr.PublicKey.(type) {
+	case *rsa.PublicKey:
+		return fmt.Sprintf("rsa:%d", key.N.BitLen())
+	case *ecdsa.PublicKey:
+		name := key.Curve.Params().Name
+		if kmiName, ok := ecCurveNames[name]; ok {
+			name = kmiName
+		}
+		return "ec:" + name
+	case ed25519.PublicKey:
+		return "ed25519"
+	}
+	return strings.ToLower(csr.PublicKeyAlgorithm.String())
+}
+
+// certificateRequestHashType returns the KMI hash type of the CSR signature.
+func certificateRequestHashType(csr *x509.CertificateRequest) string {
+	if hashType, ok := hashTypes[csr.SignatureAlgorithm]; ok {
+		return hashType
+	}
+	return strings.ToLower(csr.SignatureAlgorithm.String())
+}
+
+// globMatch reports whether value matches pattern, in which '*' matches any
+// sequence of characters.
+func globMatch(pattern string, value string) bool {
+	quoted := strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, `.*`)
+	return regexp.MustCompile("^" + quoted + "$").MatchString(value)
+}
+
+func globMatchAny(patterns []string, value string) bool {
+	for _, pattern := range patterns {
+		if globMatch(pattern, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// ipInRanges reports whether ip is one of the IP addresses or within one of the
+// CIDR ranges. A "*" range allows every address.
+func ipInRanges(ranges []string, ip net.IP) bool {
+	for _, r := range ranges {
+		if r == "*" {
+			return true
+		}
+		if _, network, err := net.ParseCIDR(r); err == nil {
+			if network.Contains(ip) {
+				return true
+			}
+			continue
+		}
+		if allowed := net.ParseIP(r); allowed != nil && allowed.Equal(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// stringInList reports whether value is in values, or values holds "*".
+func stringInList(values []string, value string) bool {
+	for _, v := range values {
+		if v == "*" || v == value {
+			return true
+		}
+	}
+	return false
+}
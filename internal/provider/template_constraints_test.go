@@ -0,0 +1,172 @@
+package provider
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"net"
+	"net/url"
+	"reflect"
+	"terraform-provider-kmi/internal/kmi"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+)
+
+// testCertificateRequest returns a PEM encoded CSR signed by key.
+func testCertificateRequest(t *testing.T, key crypto.Signer, template *x509.CertificateRequest) string {
+	t.Helper()
+	der, err := x509.CreateCertificateRequest(rand.Reader, template, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der}))
+}
+
+func TestTemplateCheckDataSourceSchema(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	schemaResponse := &datasource.SchemaResponse{}
+	NewTemplateCheckDataSource().Schema(ctx, datasource.SchemaRequest{}, schemaResponse)
+	if schemaResponse.Diagnostics.HasError() {
+		t.Fatalf("Schema method diagnostics: %+v", schemaResponse.Diagnostics)
+	}
+
+	diagnostics := schemaResponse.Schema.ValidateImplementation(ctx)
+	if diagnostics.HasError() {
+		t.Fatalf("Schema validation diagnostics: %+v", diagnostics)
+	}
+}
+
+func TestCheckCertificateRequest(t *testing.T) {
+	t.Parallel()
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	uri, _ := url.Parse("spiffe://example.com/ns/web")
+	request := &x509.CertificateRequest{
+		Subject:     pkix.Name{CommonName: "web.example.com"},
+		DNSNames:    []string{"web.example.com", "api.example.net"},
+		URIs:        []*url.URL{uri},
+		IPAddresses: []net.IP{net.ParseIP("10.1.2.3")},
+	}
+	ecCSR, err := parseCertificateRequest(testCertificateRequest(t, ecKey, request))
+	if err != nil {
+		t.Fatal(err)
+	}
+	edCSR, err := parseCertificateRequest(testCertificateRequest(t, edKey, &x509.CertificateRequest{Subject: pkix.Name{CommonName: "web.example.com"}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	constraints := func(pairs ...string) []kmi.ConstraintTypeResponse {
+		var result []kmi.ConstraintTypeResponse
+		for i := 0; i < len(pairs); i += 2 {
+			result = append(result, kmi.ConstraintTypeResponse{Type: pairs[i], Text: pairs[i+1]})
+		}
+		return result
+	}
+
+	tests := []struct {
+		name        string
+		csr         *x509.CertificateRequest
+		constraints []kmi.ConstraintTypeResponse
+		ttl         string
+		want        []string
+	}{
+		{
+			name: "allowed",
+			csr:  ecCSR,
+			constraints: constraints(
+				"common_name", "*.example.com",
+				"dns_san", "*.example.com,api.example.*",
+				"uri_san", "spiffe://example.com/*",
+				"ip_san", "10.0.0.0/8",
+				"hash_type", "sha256",
+			),
+			ttl:  "30d",
+			want: []string{},
+		},
+		{
+			name:        "sans not allowed by default",
+			csr:         ecCSR,
+			constraints: constraints("common_name", "*"),
+			want: []string{
+				`dns_san "web.example.com" is not allowed by ""`,
+				`dns_san "api.example.net" is not allowed by ""`,
+				`uri_san "spiffe://example.com/ns/web" is not allowed by ""`,
+				`ip_san "10.1.2.3" is not allowed by ""`,
+			},
+		},
+		{
+			name: "violations",
+			csr:  ecCSR,
+			constraints: constraints(
+				"common_name", "db-*.example.com",
+				"dns_san", "*.example.com",
+				"uri_san", "*",
+				"ip_san", "10.1.2.4,192.168.0.0/16",
+				"key_type", "rsa:2048,rsa:4096",
+				"hash_type", "sha384",
+				"max_ttl", "30d",
+			),
+			ttl: "60d",
+			want: []string{
+				`common_name "web.example.com" does not match "db-*.example.com"`,
+				`dns_san "api.example.net" is not allowed by "*.example.com"`,
+				`ip_san "10.1.2.3" is not allowed by "10.1.2.4,192.168.0.0/16"`,
+				`key_type "ec:secp256r1" is not allowed by "rsa:2048,rsa:4096"`,
+				`hash_type "sha256" is not allowed by "sha384"`,
+				`ttl "60d" is longer than max_ttl "30d"`,
+			},
+		},
+		{
+			name:        "ed25519 key outside default key types",
+			csr:         edCSR,
+			constraints: constraints("hash_type", "sha512"),
+			ttl:         "1d",
+			want: []string{
+				`key_type "ed25519" is not allowed by "rsa:2048,rsa:4096,ec:secp256r1"`,
+				`ttl "1d" is shorter than min_ttl "7d"`,
+			},
+		},
+		{
+			name:        "ed25519 key allowed",
+			csr:         edCSR,
+			constraints: constraints("key_type", "*"),
+			want:        []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := checkCertificateRequest(tt.csr, tt.constraints, tt.ttl)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("checkCertificateRequest() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCertificateRequestErrors(t *testing.T) {
+	t.Parallel()
+
+	for _, csr := range []string{"", "not pem", "-----BEGIN CERTIFICATE REQUEST-----\nYWJj\n-----END CERTIFICATE REQUEST-----\n"} {
+		if _, err := parseCertificateRequest(csr); err == nil {
+			t.Errorf("parseCertificateRequest(%q) succeeded, want error", csr)
+		}
+	}
+}
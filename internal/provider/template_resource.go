@@ -2,20 +2,28 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 	"terraform-provider-kmi/internal/kmi"
-	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource              = &templateResource{}
-	_ resource.ResourceWithConfigure = &templateResource{}
+	_ resource.Resource                 = &templateResource{}
+	_ resource.ResourceWithConfigure    = &templateResource{}
+	_ resource.ResourceWithUpgradeState = &templateResource{}
 )
 
 // NewOrderResource is a helper function to simplify the provider implementation.
@@ -36,44 +44,80 @@ func (r *templateResource) Metadata(_ context.Context, req resource.MetadataRequ
 // Schema defines the schema for the resource.
 func (r *templateResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 3,
 		Attributes: map[string]schema.Attribute{
 			"ca_collection": schema.StringAttribute{
 				Required:    true,
 				Description: "CA collection name to be created on KMI ",
+				Validators: []validator.String{
+					kmiNameValidator(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"ca_definition": schema.StringAttribute{
 				Required:    true,
 				Description: "CA definition name to be created on KMI ",
+				Validators: []validator.String{
+					kmiNameValidator(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"template_name": schema.StringAttribute{
 				Required:    true,
 				Description: " Certificate Signing Request template name to be created on KMI ",
+				Validators: []validator.String{
+					kmiNameValidator(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
-			"client_collection": schema.StringAttribute{
-				Required:    true,
-				Description: " Client collection name to be created on KMI ",
+			"client_collections": schema.SetAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Client collections approved to sign requests with the template. ",
+				Validators: []validator.Set{
+					kmiNamesValidator(),
+				},
 			},
 
 			"last_updated": schema.StringAttribute{
 				Computed:    true,
-				Description: " The last time the group was updated.",
+				Description: " The KMI modified timestamp of the template.",
+			},
+			"constraints": schema.MapAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "Every constraint of the template as returned by KMI, keyed by constraint type, including the defaults KMI applies to unconfigured options.",
 			},
 			"options": schema.SingleNestedAttribute{
 				Required: true,
 				Attributes: map[string]schema.Attribute{
 					"min_ttl": schema.StringAttribute{
 						Optional:    true,
-						Description: " The minimum period of time that the signed secret can be valid for Default is 7 day ",
+						CustomType:  ttlType{},
+						Description: " The minimum period of time that the signed secret can be valid for, such as \"7d\" or \"168h\". Default is 7 day ",
+						Validators: []validator.String{
+							ttlValidator{},
+						},
 					},
 					"max_ttl": schema.StringAttribute{
 						Optional:    true,
-						Description: " The maximum period of time that the signed secret can be valid for Default is 90 days",
+						CustomType:  ttlType{},
+						Description: " The maximum period of time that the signed secret can be valid for, such as \"90d\" or \"2160h\". Default is 90 days",
+						Validators: []validator.String{
+							ttlValidator{},
+						},
 					},
-					"leaf_exceeds_ca_ttl": schema.StringAttribute{
+					"leaf_exceeds_ca_ttl": schema.BoolAttribute{
 						Optional:    true,
-						Description: " Boolean flag as to whether or not the signed secret's notAfter date can exceed that of the CA certificate ",
+						Description: " Whether or not the signed secret's notAfter date can exceed that of the CA certificate ",
 					},
-					"allow_ca": schema.StringAttribute{
+					"allow_ca": schema.BoolAttribute{
 						Optional:    true,
 						Description: " Whether the signed secret can have the CA option set in the BasicConstraints extension.",
 					},
@@ -81,25 +125,45 @@ func (r *templateResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 						Optional:    true,
 						Description: " Common name of the certificate. Can be \"*\" to allow all values, or a string with '*' as a glob character",
 					},
-					"dns_san": schema.StringAttribute{
+					"dns_san": schema.SetAttribute{
 						Optional:    true,
-						Description: " Comma delimited list of acceptable domain names for the Subject Alternative Name extension. Names use '*' as a glob character Default no values allowed	",
+						ElementType: types.StringType,
+						Description: " Acceptable domain names for the Subject Alternative Name extension. Names use '*' as a glob character Default no values allowed	",
+						Validators: []validator.Set{
+							dnsNamesValidator(true),
+						},
 					},
-					"uri_san": schema.StringAttribute{
+					"uri_san": schema.SetAttribute{
 						Optional:    true,
-						Description: "Comma delimited list of acceptable URIs for the Subject Alternative Name extension. Names use '*' as a glob character. Default no values allowed",
+						ElementType: types.StringType,
+						Description: "Acceptable URIs for the Subject Alternative Name extension. Names use '*' as a glob character. Default no values allowed",
+						Validators: []validator.Set{
+							urisValidator(true),
+						},
 					},
-					"ip_san": schema.StringAttribute{
+					"ip_san": schema.SetAttribute{
 						Optional:    true,
-						Description: "Comma delimited list of acceptable IPs for the Subject Alternative Name extension. Can be IP addresses or CIDRs. Default no values allowed",
+						ElementType: types.StringType,
+						Description: "Acceptable IPs for the Subject Alternative Name extension. Can be IP addresses or CIDRs. Default no values allowed",
+						Validators: []validator.Set{
+							ipsOrCIDRsValidator(),
+						},
 					},
-					"key_type": schema.StringAttribute{
+					"key_type": schema.SetAttribute{
 						Optional:    true,
-						Description: "Comma delimited list of acceptable key types for the signed certificate. Can be '*' to allow all key types.Default rsa:2048,rsa:4096,ec:secp256r1",
+						ElementType: types.StringType,
+						Description: "Acceptable key types for the signed certificate. Can be '*' to allow all key types.Default rsa:2048,rsa:4096,ec:secp256r1",
+						Validators: []validator.Set{
+							setOneOfValidator(templateKeyTypes...),
+						},
 					},
-					"hash_type": schema.StringAttribute{
+					"hash_type": schema.SetAttribute{
 						Optional:    true,
-						Description: "Comma delimited list of acceptable hash_types for the signed certificate. Can be '*' to allow all key types. This constraint is ignored for key_types that don't use hashing as part of the signature (ed25519)",
+						ElementType: types.StringType,
+						Description: "Acceptable hash_types for the signed certificate. Can be '*' to allow all hash types. This constraint is ignored for key_types that don't use hashing as part of the signature (ed25519)",
+						Validators: []validator.Set{
+							setOneOfValidator(templateHashTypes...),
+						},
 					},
 				},
 			},
@@ -107,6 +171,121 @@ func (r *templateResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 	}
 }
 
+var (
+	// templateKeyTypes are the key types a template can accept.
+	templateKeyTypes = []string{"*", "rsa:2048", "rsa:3072", "rsa:4096", "ec:secp256r1", "ec:secp384r1", "ec:secp521r1", "ed25519"}
+	// templateHashTypes are the hash types a template can accept.
+	templateHashTypes = []string{"*", "sha256", "sha384", "sha512"}
+)
+
+// UpgradeState migrates state written by earlier versions of the schema.
+func (r *templateResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// Version 0 stored dns_san, uri_san and ip_san as comma delimited strings.
+		0: {
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				upgradeTemplateState(req, resp, func(rawState map[string]any) {
+					upgradeTemplateSANs(rawState)
+					upgradeClientCollections(rawState)
+					upgradeTypedConstraints(rawState)
+				})
+			},
+		},
+		// Version 1 approved a single client_collection.
+		1: {
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				upgradeTemplateState(req, resp, func(rawState map[string]any) {
+					upgradeClientCollections(rawState)
+					upgradeTypedConstraints(rawState)
+				})
+			},
+		},
+		// Version 2 stored the boolean constraints and key_type and hash_type as strings.
+		2: {
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				upgradeTemplateState(req, resp, upgradeTypedConstraints)
+			},
+		},
+	}
+}
+
+// upgradeTemplateState applies upgrade to the raw JSON state of a template.
+func upgradeTemplateState(req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse, upgrade func(map[string]any)) {
+	var rawState map[string]any
+	err := json.Unmarshal(req.RawState.JSON, &rawState)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Upgrading Template State",
+			"Could not parse prior state, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	upgrade(rawState)
+
+	upgraded, err := json.Marshal(rawState)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Upgrading Template State",
+			"Could not encode upgraded state, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	resp.DynamicValue = &tfprotov6.DynamicValue{JSON: upgraded}
+}
+
+// upgradeTemplateSANs converts the comma delimited SAN constraints into lists.
+func upgradeTemplateSANs(rawState map[string]any) {
+	if options, ok := rawState["options"].(map[string]any); ok {
+		for _, name := range []string{"dns_san", "uri_san", "ip_san"} {
+			if value, ok := options[name].(string); ok {
+				options[name] = splitCommaList(value)
+			}
+		}
+	}
+}
+
+// upgradeTypedConstraints converts the boolean constraints into booleans and the
+// comma delimited key_type and hash_type constraints into lists.
+func upgradeTypedConstraints(rawState map[string]any) {
+	options, ok := rawState["options"].(map[string]any)
+	if !ok {
+		return
+	}
+	// A blank legacy value sets no constraint, so it becomes null.
+	for _, name := range []string{"allow_ca", "leaf_exceeds_ca_ttl"} {
+		if value, ok := options[name].(string); ok {
+			options[name] = nil
+			if strings.TrimSpace(value) != "" {
+				options[name] = parseKMIBool(value)
+			}
+		}
+	}
+	for _, name := range []string{"key_type", "hash_type"} {
+		if value, ok := options[name].(string); ok {
+			options[name] = nil
+			if strings.TrimSpace(value) != "" {
+				options[name] = splitCommaList(value)
+			}
+		}
+	}
+}
+
+// parseKMIBool parses the boolean form used by KMI constraints.
+func parseKMIBool(value string) bool {
+	value = strings.TrimSpace(value)
+	return strings.EqualFold(value, "true") || value == "1"
+}
+
+// upgradeClientCollections moves client_collection into the client_collections set.
+func upgradeClientCollections(rawState map[string]any) {
+	if value, ok := rawState["client_collection"].(string); ok && value != "" {
+		rawState["client_collections"] = []string{value}
+	} else {
+		rawState["client_collections"] = nil
+	}
+	delete(rawState, "client_collection")
+}
+
 // Create creates the resource and sets the initial Terraform state.
 func (r *templateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan templateResourceModel
@@ -139,21 +318,20 @@ func (r *templateResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
-	kmiSigner := kmi.Template{
-		Collectionacl: &kmi.ApproveClientCollection{
-			Target: plan.ClientCollectionName.ValueString(),
-		},
+	r.approveClientCollections(ctx, plan, setStrings(plan.ClientCollections), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	tflog.Debug(ctx, "CreateTemplateOrSign CSR signer")
-	err = r.client.CreateTemplateOrSign(plan.CACollectionName.ValueString(), plan.CADefinitionName.ValueString(), plan.TemplateName.ValueString(), kmiSigner)
+	templateDetails, err := r.client.GetTemplate(plan.CACollectionName.ValueString(), plan.CADefinitionName.ValueString(), plan.TemplateName.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Error signing the request",
-			"Could not signing the request, unexpected error: "+err.Error(),
+			"Error Reading Template",
+			"Could not read Template "+plan.TemplateName.ValueString()+": "+err.Error(),
 		)
 		return
 	}
-	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+	plan.LastUpdated = types.StringValue(templateDetails.Modified)
+	plan.Constraints = templateConstraints(templateDetails)
 
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
@@ -163,6 +341,110 @@ func (r *templateResource) Create(ctx context.Context, req resource.CreateReques
 
 }
 
+// readTemplateConstraints maps the constraints returned by KMI onto the options
+// configured in prior. Constraints of unconfigured options, such as the
+// common_name "*" KMI defaults to or the defaults it restores once a constraint
+// is removed, are left out and only show up in the constraints attribute.
+// Configured constraints which are not returned are null.
+func readTemplateConstraints(templateDetails *kmi.TemplateResponse, prior *templateResourceModelOptions) *templateResourceModelOptions {
+	if prior == nil {
+		prior = &templateResourceModelOptions{}
+	}
+	options := &templateResourceModelOptions{
+		Minttl:    ttlNull(),
+		Max_ttl:   ttlNull(),
+		Dns_san:   types.SetNull(types.StringType),
+		Uri_san:   types.SetNull(types.StringType),
+		Ip_san:    types.SetNull(types.StringType),
+		Key_type:  types.SetNull(types.StringType),
+		Hash_type: types.SetNull(types.StringType),
+	}
+	for _, v := range templateDetails.Constraints {
+		switch {
+		case v.Type == "common_name" && !prior.CommonName.IsNull():
+			options.CommonName = types.StringValue(v.Text)
+		case v.Type == "dns_san" && !prior.Dns_san.IsNull():
+			options.Dns_san = splitToSet(v.Text)
+		case v.Type == "uri_san" && !prior.Uri_san.IsNull():
+			options.Uri_san = splitToSet(v.Text)
+		case v.Type == "ip_san" && !prior.Ip_san.IsNull():
+			options.Ip_san = splitToSet(v.Text)
+		case v.Type == "key_type" && !prior.Key_type.IsNull():
+			options.Key_type = splitToSet(v.Text)
+		case v.Type == "hash_type" && !prior.Hash_type.IsNull():
+			options.Hash_type = splitToSet(v.Text)
+		case v.Type == "allow_ca" && !prior.Allow_ca.IsNull():
+			options.Allow_ca = types.BoolValue(parseKMIBool(v.Text))
+		case v.Type == "leaf_exceeds_ca_ttl" && !prior.Leaf_exceeds_ca_ttl.IsNull():
+			options.Leaf_exceeds_ca_ttl = types.BoolValue(parseKMIBool(v.Text))
+		case v.Type == "max_ttl" && !prior.Max_ttl.IsNull():
+			options.Max_ttl = ttlString(v.Text)
+		case v.Type == "min_ttl" && !prior.Minttl.IsNull():
+			options.Minttl = ttlString(v.Text)
+		}
+	}
+	return options
+}
+
+// templateConstraints returns every constraint of the template keyed by type.
+func templateConstraints(templateDetails *kmi.TemplateResponse) types.Map {
+	constraints := map[string]attr.Value{}
+	for _, v := range templateDetails.Constraints {
+		constraints[v.Type] = types.StringValue(v.Text)
+	}
+	return types.MapValueMust(types.StringType, constraints)
+}
+
+// removedConstraints returns the types of the constraints of prior which are no
+// longer part of plan.
+func removedConstraints(prior templateResourceModel, plan templateResourceModel) []string {
+	planned := map[string]bool{}
+	for _, constraint := range generateKmiTemplate(plan, nil).Constraints {
+		planned[constraint.Type] = true
+	}
+	var removed []string
+	for _, constraint := range generateKmiTemplate(prior, nil).Constraints {
+		if !planned[constraint.Type] {
+			removed = append(removed, constraint.Type)
+		}
+	}
+	return removed
+}
+
+// approveClientCollections approves each of clientCollections to sign requests
+// with the template.
+func (r *templateResource) approveClientCollections(ctx context.Context, plan templateResourceModel, clientCollections []string, diags *diag.Diagnostics) {
+	for _, clientCollection := range clientCollections {
+		kmiSigner := kmi.Template{
+			Collectionacl: &kmi.ApproveClientCollection{
+				Target: clientCollection,
+			},
+		}
+		tflog.Debug(ctx, "CreateTemplateOrSign CSR signer "+clientCollection)
+		err := r.client.CreateTemplateOrSign(plan.CACollectionName.ValueString(), plan.CADefinitionName.ValueString(), plan.TemplateName.ValueString(), kmiSigner)
+		if err != nil {
+			diags.AddError(
+				"Error signing the request",
+				"Could not approve client collection "+clientCollection+", unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
+}
+
+// clientCollectionACLs returns the client collections approved on the template.
+// An empty set in prior is kept when KMI returns no approvals.
+func clientCollectionACLs(templateDetails *kmi.TemplateResponse, prior types.Set) types.Set {
+	var clientCollections []string
+	for _, acl := range templateDetails.Collectionacl {
+		clientCollections = append(clientCollections, acl.Target)
+	}
+	if len(clientCollections) == 0 && prior.IsNull() {
+		return types.SetNull(types.StringType)
+	}
+	return stringsToSet(clientCollections)
+}
+
 func generateKmiTemplate(plan templateResourceModel, constraintTypes []kmi.ConstraintType) kmi.Template {
 	options := plan.Options
 	if !options.CommonName.IsNull() {
@@ -174,43 +456,43 @@ func generateKmiTemplate(plan templateResourceModel, constraintTypes []kmi.Const
 	if !options.Dns_san.IsNull() {
 		constraintTypes = append(constraintTypes, kmi.ConstraintType{
 			Type: "dns_san",
-			Text: options.Dns_san.ValueString(),
+			Text: joinSet(options.Dns_san),
 		})
 	}
 	if !options.Uri_san.IsNull() {
 		constraintTypes = append(constraintTypes, kmi.ConstraintType{
 			Type: "uri_san",
-			Text: options.Uri_san.ValueString(),
+			Text: joinSet(options.Uri_san),
 		})
 	}
 	if !options.Ip_san.IsNull() {
 		constraintTypes = append(constraintTypes, kmi.ConstraintType{
 			Type: "ip_san",
-			Text: options.Ip_san.ValueString(),
+			Text: joinSet(options.Ip_san),
 		})
 	}
 	if !options.Key_type.IsNull() {
 		constraintTypes = append(constraintTypes, kmi.ConstraintType{
 			Type: "key_type",
-			Text: options.Key_type.ValueString(),
+			Text: joinSet(options.Key_type),
 		})
 	}
 	if !options.Hash_type.IsNull() {
 		constraintTypes = append(constraintTypes, kmi.ConstraintType{
 			Type: "hash_type",
-			Text: options.Hash_type.ValueString(),
+			Text: joinSet(options.Hash_type),
 		})
 	}
 	if !options.Allow_ca.IsNull() {
 		constraintTypes = append(constraintTypes, kmi.ConstraintType{
 			Type: "allow_ca",
-			Text: options.Allow_ca.ValueString(),
+			Text: boolStr(options.Allow_ca.ValueBool()),
 		})
 	}
 	if !options.Leaf_exceeds_ca_ttl.IsNull() {
 		constraintTypes = append(constraintTypes, kmi.ConstraintType{
 			Type: "leaf_exceeds_ca_ttl",
-			Text: options.Leaf_exceeds_ca_ttl.ValueString(),
+			Text: boolStr(options.Leaf_exceeds_ca_ttl.ValueBool()),
 		})
 	}
 	if !options.Max_ttl.IsNull() {
@@ -250,40 +532,10 @@ func (r *templateResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
-	state.Options = &templateResourceModelOptions{}
-	for _, v := range templateDetails.Constraints {
-		if (v.Type == "common_name") && (v.Text != "*") {
-			state.Options.CommonName = types.StringValue(v.Text)
-		}
-		if v.Type == "dns_san" {
-			state.Options.Dns_san = types.StringValue(v.Text)
-		}
-		if v.Type == "uri_san" {
-			state.Options.Uri_san = types.StringValue(v.Text)
-		}
-		if v.Type == "ip_san" {
-			state.Options.Ip_san = types.StringValue(v.Text)
-		}
-		if v.Type == "key_type" {
-			state.Options.Key_type = types.StringValue(v.Text)
-		}
-		if v.Type == "hash_type" {
-			state.Options.Hash_type = types.StringValue(v.Text)
-		}
-		if v.Type == "allow_ca" {
-			state.Options.Allow_ca = types.StringValue(v.Text)
-		}
-		if v.Type == "leaf_exceeds_ca_ttl" {
-			state.Options.Leaf_exceeds_ca_ttl = types.StringValue(v.Text)
-		}
-		if v.Type == "max_ttl" {
-			state.Options.Max_ttl = types.StringValue(v.Text)
-		}
-		if v.Type == "min_ttl" {
-			state.Options.Minttl = types.StringValue(v.Text)
-		}
-
-	}
+	state.LastUpdated = types.StringValue(templateDetails.Modified)
+	state.ClientCollections = clientCollectionACLs(templateDetails, state.ClientCollections)
+	state.Options = readTemplateConstraints(templateDetails, state.Options)
+	state.Constraints = templateConstraints(templateDetails)
 
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -303,6 +555,8 @@ func (r *templateResource) Update(ctx context.Context, req resource.UpdateReques
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	var state templateResourceModel
+	diags = req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -325,21 +579,43 @@ func (r *templateResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
-	kmiSigner := kmi.Template{
-		Collectionacl: &kmi.ApproveClientCollection{
-			Target: plan.ClientCollectionName.ValueString(),
-		},
+	for _, constraintType := range removedConstraints(state, plan) {
+		tflog.Debug(ctx, "DeleteTemplateConstraint "+constraintType)
+		err = r.client.DeleteTemplateConstraint(plan.CACollectionName.ValueString(), plan.CADefinitionName.ValueString(), plan.TemplateName.ValueString(), constraintType)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating Template",
+				"Could not remove constraint "+constraintType+", unexpected error: "+err.Error(),
+			)
+			return
+		}
 	}
-	tflog.Debug(ctx, "CreateTemplateOrSign CSR signer")
-	err = r.client.CreateTemplateOrSign(plan.CACollectionName.ValueString(), plan.CADefinitionName.ValueString(), plan.TemplateName.ValueString(), kmiSigner)
+
+	r.approveClientCollections(ctx, plan, setDifference(plan.ClientCollections, state.ClientCollections), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for _, clientCollection := range setDifference(state.ClientCollections, plan.ClientCollections) {
+		tflog.Debug(ctx, "DeleteTemplateCollectionACL "+clientCollection)
+		err = r.client.DeleteTemplateCollectionACL(plan.CACollectionName.ValueString(), plan.CADefinitionName.ValueString(), plan.TemplateName.ValueString(), clientCollection)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error revoking client collection",
+				"Could not revoke client collection "+clientCollection+", unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
+	templateDetails, err := r.client.GetTemplate(plan.CACollectionName.ValueString(), plan.CADefinitionName.ValueString(), plan.TemplateName.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Error signing the request",
-			"Could not signing the request, unexpected error: "+err.Error(),
+			"Error Reading Template",
+			"Could not read Template "+plan.TemplateName.ValueString()+": "+err.Error(),
 		)
 		return
 	}
-	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+	plan.LastUpdated = types.StringValue(templateDetails.Modified)
+	plan.Constraints = templateConstraints(templateDetails)
 
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
@@ -388,22 +664,23 @@ func (r *templateResource) Configure(_ context.Context, req resource.ConfigureRe
 }
 
 type templateResourceModel struct {
-	CACollectionName     types.String                  `tfsdk:"ca_collection"`
-	CADefinitionName     types.String                  `tfsdk:"ca_definition"`
-	TemplateName         types.String                  `tfsdk:"template_name"`
-	ClientCollectionName types.String                  `tfsdk:"client_collection"`
-	Options              *templateResourceModelOptions `tfsdk:"options"`
-	LastUpdated          types.String                  `tfsdk:"last_updated"`
+	CACollectionName  types.String                  `tfsdk:"ca_collection"`
+	CADefinitionName  types.String                  `tfsdk:"ca_definition"`
+	TemplateName      types.String                  `tfsdk:"template_name"`
+	ClientCollections types.Set                     `tfsdk:"client_collections"`
+	Options           *templateResourceModelOptions `tfsdk:"options"`
+	LastUpdated       types.String                  `tfsdk:"last_updated"`
+	Constraints       types.Map                     `tfsdk:"constraints"`
 }
 type templateResourceModelOptions struct {
-	Minttl              types.String `tfsdk:"min_ttl"`
-	Max_ttl             types.String `tfsdk:"max_ttl"`
-	Leaf_exceeds_ca_ttl types.String `tfsdk:"leaf_exceeds_ca_ttl"`
-	Allow_ca            types.String `tfsdk:"allow_ca"`
+	Minttl              ttlValue     `tfsdk:"min_ttl"`
+	Max_ttl             ttlValue     `tfsdk:"max_ttl"`
+	Leaf_exceeds_ca_ttl types.Bool   `tfsdk:"leaf_exceeds_ca_ttl"`
+	Allow_ca            types.Bool   `tfsdk:"allow_ca"`
 	CommonName          types.String `tfsdk:"common_name"`
-	Dns_san             types.String `tfsdk:"dns_san"`
-	Uri_san             types.String `tfsdk:"uri_san"`
-	Ip_san              types.String `tfsdk:"ip_san"`
-	Key_type            types.String `tfsdk:"key_type"`
-	Hash_type           types.String `tfsdk:"hash_type"`
+	Dns_san             types.Set    `tfsdk:"dns_san"`
+	Uri_san             types.Set    `tfsdk:"uri_san"`
+	Ip_san              types.Set    `tfsdk:"ip_san"`
+	Key_type            types.Set    `tfsdk:"key_type"`
+	Hash_type           types.Set    `tfsdk:"hash_type"`
 }
@@ -0,0 +1,374 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"reflect"
+	"terraform-provider-kmi/internal/kmi"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+func TestTemplateResourceSchema(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	schemaRequest := fwresource.SchemaRequest{}
+	schemaResponse := &fwresource.SchemaResponse{}
+
+	NewTemplateResource().Schema(ctx, schemaRequest, schemaResponse)
+
+	if schemaResponse.Diagnostics.HasError() {
+		t.Fatalf("Schema method diagnostics: %+v", schemaResponse.Diagnostics)
+	}
+
+	diagnostics := schemaResponse.Schema.ValidateImplementation(ctx)
+
+	if diagnostics.HasError() {
+		t.Fatalf("Schema validation diagnostics: %+v", diagnostics)
+	}
+}
+
+func TestGenerateKmiTemplateSANs(t *testing.T) {
+	plan := templateResourceModel{
+		Options: &templateResourceModelOptions{
+			Dns_san: types.SetValueMust(types.StringType, []attr.Value{
+				types.StringValue("web-*.example.com"),
+				types.StringValue("*.example.net"),
+			}),
+			Uri_san: types.SetNull(types.StringType),
+			Ip_san: types.SetValueMust(types.StringType, []attr.Value{
+				types.StringValue("10.0.0.0/8"),
+			}),
+		},
+	}
+
+	out, _ := xml.MarshalIndent(generateKmiTemplate(plan, nil), "", "")
+	data := `<template><constraint type="dns_san">*.example.net,web-*.example.com</constraint><constraint type="ip_san">10.0.0.0/8</constraint></template>`
+	if !reflect.DeepEqual(string(out), data) {
+		t.Errorf("Marshalling() = %v, want %v", string(out), data)
+	}
+}
+
+func TestTemplateUpgradeStateV0(t *testing.T) {
+	ctx := context.Background()
+	r, ok := NewTemplateResource().(fwresource.ResourceWithUpgradeState)
+	if !ok {
+		t.Fatal("template resource does not implement ResourceWithUpgradeState")
+	}
+
+	req := fwresource.UpgradeStateRequest{
+		RawState: &tfprotov6.RawState{
+			JSON: []byte(`{"template_name":"tmpl","client_collection":"web_certs","options":{"dns_san":"a.example.com,*.example.net","ip_san":null}}`),
+		},
+	}
+	resp := &fwresource.UpgradeStateResponse{}
+	r.UpgradeState(ctx)[0].StateUpgrader(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("UpgradeState() diagnostics = %v", resp.Diagnostics)
+	}
+
+	var upgraded struct {
+		ClientCollections []string       `json:"client_collections"`
+		Options           map[string]any `json:"options"`
+	}
+	if err := json.Unmarshal(resp.DynamicValue.JSON, &upgraded); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(upgraded.ClientCollections, []string{"web_certs"}) {
+		t.Errorf("UpgradeState() client_collections = %v, want [web_certs]", upgraded.ClientCollections)
+	}
+	want := []any{"a.example.com", "*.example.net"}
+	if !reflect.DeepEqual(upgraded.Options["dns_san"], want) {
+		t.Errorf("UpgradeState() dns_san = %v, want %v", upgraded.Options["dns_san"], want)
+	}
+	if upgraded.Options["ip_san"] != nil {
+		t.Errorf("UpgradeState() ip_san = %v, want nil", upgraded.Options["ip_san"])
+	}
+}
+
+func TestTemplateUpgradeStateV1(t *testing.T) {
+	ctx := context.Background()
+	r, ok := NewTemplateResource().(fwresource.ResourceWithUpgradeState)
+	if !ok {
+		t.Fatal("template resource does not implement ResourceWithUpgradeState")
+	}
+
+	for input, want := range map[string][]string{
+		`{"template_name":"tmpl","client_collection":"web_certs","options":{}}`: {"web_certs"},
+		`{"template_name":"tmpl","client_collection":null,"options":{}}`:        nil,
+	} {
+		req := fwresource.UpgradeStateRequest{
+			RawState: &tfprotov6.RawState{JSON: []byte(input)},
+		}
+		resp := &fwresource.UpgradeStateResponse{}
+		r.UpgradeState(ctx)[1].StateUpgrader(ctx, req, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("UpgradeState() diagnostics = %v", resp.Diagnostics)
+		}
+
+		var upgraded map[string]any
+		if err := json.Unmarshal(resp.DynamicValue.JSON, &upgraded); err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := upgraded["client_collection"]; ok {
+			t.Errorf("UpgradeState(%s) kept client_collection", input)
+		}
+		var got []string
+		if values, ok := upgraded["client_collections"].([]any); ok {
+			for _, value := range values {
+				got = append(got, value.(string))
+			}
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("UpgradeState(%s) client_collections = %v, want %v", input, got, want)
+		}
+	}
+}
+
+func TestClientCollectionACLs(t *testing.T) {
+	t.Parallel()
+
+	details := &kmi.TemplateResponse{
+		Collectionacl: []kmi.ApproveClientCollection{{Target: "web_certs"}, {Target: "PIM_SECRETS"}},
+	}
+	got := clientCollectionACLs(details, types.SetNull(types.StringType))
+	if want := []string{"PIM_SECRETS", "web_certs"}; !reflect.DeepEqual(setStrings(got), want) {
+		t.Errorf("clientCollectionACLs() = %v, want %v", got, want)
+	}
+
+	empty := types.SetValueMust(types.StringType, []attr.Value{})
+	if got := clientCollectionACLs(&kmi.TemplateResponse{}, empty); got.IsNull() || len(got.Elements()) != 0 {
+		t.Errorf("clientCollectionACLs() = %v, want empty set", got)
+	}
+	if got := clientCollectionACLs(&kmi.TemplateResponse{}, types.SetNull(types.StringType)); !got.IsNull() {
+		t.Errorf("clientCollectionACLs() = %v, want null", got)
+	}
+
+	prior := types.SetValueMust(types.StringType, []attr.Value{types.StringValue("a"), types.StringValue("b")})
+	plan := types.SetValueMust(types.StringType, []attr.Value{types.StringValue("b"), types.StringValue("c")})
+	if got := setDifference(plan, prior); !reflect.DeepEqual(got, []string{"c"}) {
+		t.Errorf("setDifference() added = %v, want [c]", got)
+	}
+	if got := setDifference(prior, plan); !reflect.DeepEqual(got, []string{"a"}) {
+		t.Errorf("setDifference() removed = %v, want [a]", got)
+	}
+}
+
+func TestReadTemplateConstraints(t *testing.T) {
+	t.Parallel()
+
+	details := &kmi.TemplateResponse{
+		Constraints: []kmi.ConstraintTypeResponse{
+			{Type: "common_name", Text: "*"},
+			{Type: "dns_san", Text: "*.example.com,web.example.net"},
+			{Type: "max_ttl", Text: "30 days"},
+			{Type: "subject_ou", Text: "web"},
+		},
+	}
+	prior := &templateResourceModelOptions{
+		CommonName: types.StringValue("web.example.com"),
+		Dns_san:    types.SetValueMust(types.StringType, []attr.Value{types.StringValue("*.example.com")}),
+		Ip_san:     types.SetValueMust(types.StringType, []attr.Value{types.StringValue("10.0.0.0/8")}),
+		Max_ttl:    ttlString("30d"),
+		Minttl:     ttlString("1d"),
+	}
+	options := readTemplateConstraints(details, prior)
+	if options.CommonName.ValueString() != "*" {
+		t.Errorf("readTemplateConstraints() common_name = %v, want *", options.CommonName)
+	}
+	if got, want := setStrings(options.Dns_san), []string{"*.example.com", "web.example.net"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("readTemplateConstraints() dns_san = %v, want %v", got, want)
+	}
+	if options.Max_ttl.ValueString() != "30 days" {
+		t.Errorf("readTemplateConstraints() max_ttl = %v, want 30 days", options.Max_ttl)
+	}
+	if !options.Ip_san.IsNull() || !options.Minttl.IsNull() {
+		t.Errorf("readTemplateConstraints() ip_san = %v, min_ttl = %v, want null", options.Ip_san, options.Minttl)
+	}
+
+	constraints := templateConstraints(details)
+	if got := constraints.Elements()["subject_ou"]; !got.Equal(types.StringValue("web")) {
+		t.Errorf("templateConstraints() subject_ou = %v, want web", got)
+	}
+	if len(constraints.Elements()) != 4 {
+		t.Errorf("templateConstraints() = %v, want 4 constraints", constraints)
+	}
+}
+
+func TestReadTemplateConstraintsDefaults(t *testing.T) {
+	t.Parallel()
+
+	// KMI returns its default common_name and the max_ttl it restored after
+	// the constraint was removed, neither of which is configured.
+	details := &kmi.TemplateResponse{
+		Constraints: []kmi.ConstraintTypeResponse{
+			{Type: "common_name", Text: "*"},
+			{Type: "max_ttl", Text: "90 days"},
+			{Type: "dns_san", Text: "*.example.com"},
+		},
+	}
+	prior := &templateResourceModelOptions{
+		Minttl:    ttlNull(),
+		Max_ttl:   ttlNull(),
+		Dns_san:   types.SetValueMust(types.StringType, []attr.Value{types.StringValue("*.example.com")}),
+		Uri_san:   types.SetNull(types.StringType),
+		Ip_san:    types.SetNull(types.StringType),
+		Key_type:  types.SetNull(types.StringType),
+		Hash_type: types.SetNull(types.StringType),
+	}
+
+	options := readTemplateConstraints(details, prior)
+	if !options.CommonName.IsNull() || !options.Max_ttl.IsNull() {
+		t.Errorf("readTemplateConstraints() common_name = %v, max_ttl = %v, want null", options.CommonName, options.Max_ttl)
+	}
+	if got, want := setStrings(options.Dns_san), []string{"*.example.com"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("readTemplateConstraints() dns_san = %v, want %v", got, want)
+	}
+	if got := templateConstraints(details).Elements()["common_name"]; !got.Equal(types.StringValue("*")) {
+		t.Errorf("templateConstraints() common_name = %v, want *", got)
+	}
+
+	plan := templateResourceModel{Options: prior}
+	state := templateResourceModel{Options: options}
+	if removed := removedConstraints(state, plan); len(removed) != 0 {
+		t.Errorf("removedConstraints() = %v, want none", removed)
+	}
+}
+
+func TestRemovedConstraints(t *testing.T) {
+	t.Parallel()
+
+	prior := templateResourceModel{
+		Options: &templateResourceModelOptions{
+			CommonName: types.StringValue("*"),
+			Dns_san:    types.SetValueMust(types.StringType, []attr.Value{types.StringValue("*.example.com")}),
+			Uri_san:    types.SetNull(types.StringType),
+			Ip_san:     types.SetValueMust(types.StringType, []attr.Value{types.StringValue("10.0.0.0/8")}),
+			Max_ttl:    ttlString("30d"),
+		},
+	}
+	plan := templateResourceModel{
+		Options: &templateResourceModelOptions{
+			CommonName: types.StringValue("web.example.com"),
+			Dns_san:    types.SetValueMust(types.StringType, []attr.Value{types.StringValue("*.example.com")}),
+			Uri_san:    types.SetNull(types.StringType),
+			Ip_san:     types.SetNull(types.StringType),
+			Minttl:     ttlString("1d"),
+		},
+	}
+
+	got := removedConstraints(prior, plan)
+	want := []string{"ip_san", "max_ttl"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("removedConstraints() = %v, want %v", got, want)
+	}
+}
+
+func TestGenerateKmiTemplateTypedConstraints(t *testing.T) {
+	plan := templateResourceModel{
+		Options: &templateResourceModelOptions{
+			Minttl:              ttlString("1d"),
+			Max_ttl:             ttlString("2160h"),
+			Leaf_exceeds_ca_ttl: types.BoolValue(false),
+			Allow_ca:            types.BoolValue(true),
+			Dns_san:             types.SetNull(types.StringType),
+			Uri_san:             types.SetNull(types.StringType),
+			Ip_san:              types.SetNull(types.StringType),
+			Key_type: types.SetValueMust(types.StringType, []attr.Value{
+				types.StringValue("rsa:4096"),
+				types.StringValue("ec:secp256r1"),
+			}),
+			Hash_type: types.SetValueMust(types.StringType, []attr.Value{types.StringValue("sha256")}),
+		},
+	}
+
+	out, _ := xml.MarshalIndent(generateKmiTemplate(plan, nil), "", "")
+	data := `<template><constraint type="key_type">ec:secp256r1,rsa:4096</constraint><constraint type="hash_type">sha256</constraint><constraint type="allow_ca">True</constraint><constraint type="leaf_exceeds_ca_ttl">False</constraint><constraint type="max_ttl">2160h</constraint><constraint type="min_ttl">1d</constraint></template>`
+	if !reflect.DeepEqual(string(out), data) {
+		t.Errorf("Marshalling() = %v, want %v", string(out), data)
+	}
+
+	options := readTemplateConstraints(&kmi.TemplateResponse{
+		Constraints: []kmi.ConstraintTypeResponse{
+			{Type: "allow_ca", Text: "True"},
+			{Type: "leaf_exceeds_ca_ttl", Text: "False"},
+			{Type: "key_type", Text: "rsa:4096,ec:secp256r1"},
+		},
+	}, plan.Options)
+	if !options.Allow_ca.ValueBool() || options.Leaf_exceeds_ca_ttl.ValueBool() {
+		t.Errorf("readTemplateConstraints() allow_ca = %v, leaf_exceeds_ca_ttl = %v", options.Allow_ca, options.Leaf_exceeds_ca_ttl)
+	}
+	if got, want := setStrings(options.Key_type), []string{"ec:secp256r1", "rsa:4096"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("readTemplateConstraints() key_type = %v, want %v", got, want)
+	}
+	if !options.Hash_type.IsNull() || !options.Minttl.IsNull() {
+		t.Errorf("readTemplateConstraints() hash_type = %v, min_ttl = %v, want null", options.Hash_type, options.Minttl)
+	}
+}
+
+func TestTemplateUpgradeStateV2(t *testing.T) {
+	ctx := context.Background()
+	r, ok := NewTemplateResource().(fwresource.ResourceWithUpgradeState)
+	if !ok {
+		t.Fatal("template resource does not implement ResourceWithUpgradeState")
+	}
+
+	tests := []struct {
+		name    string
+		options string
+		want    map[string]any
+	}{
+		{
+			name:    "typed",
+			options: `{"allow_ca":"True","leaf_exceeds_ca_ttl":"false","key_type":"rsa:2048,ed25519","hash_type":null,"max_ttl":"90 days"}`,
+			want: map[string]any{
+				"allow_ca":            true,
+				"leaf_exceeds_ca_ttl": false,
+				"key_type":            []any{"rsa:2048", "ed25519"},
+				"hash_type":           nil,
+				"max_ttl":             "90 days",
+			},
+		},
+		{
+			name:    "blank",
+			options: `{"allow_ca":"","leaf_exceeds_ca_ttl":" ","key_type":"","hash_type":""}`,
+			want: map[string]any{
+				"allow_ca":            nil,
+				"leaf_exceeds_ca_ttl": nil,
+				"key_type":            nil,
+				"hash_type":           nil,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := fwresource.UpgradeStateRequest{
+				RawState: &tfprotov6.RawState{
+					JSON: []byte(`{"template_name":"tmpl","options":` + tt.options + `}`),
+				},
+			}
+			resp := &fwresource.UpgradeStateResponse{}
+			r.UpgradeState(ctx)[2].StateUpgrader(ctx, req, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("UpgradeState() diagnostics = %v", resp.Diagnostics)
+			}
+
+			var upgraded struct {
+				Options map[string]any `json:"options"`
+			}
+			if err := json.Unmarshal(resp.DynamicValue.JSON, &upgraded); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(upgraded.Options, tt.want) {
+				t.Errorf("UpgradeState() options = %v, want %v", upgraded.Options, tt.want)
+			}
+		})
+	}
+}
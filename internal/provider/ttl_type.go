@@ -0,0 +1,157 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var (
+	_ basetypes.StringTypable                    = ttlType{}
+	_ basetypes.StringValuableWithSemanticEquals = ttlValue{}
+)
+
+// ttlTermRegex matches a single term of a TTL such as "7d", "12 hours" or "30m".
+var ttlTermRegex = regexp.MustCompile(`^([0-9]+) ?(seconds?|secs?|s|minutes?|mins?|m|hours?|h|days?|d|weeks?|w)`)
+
+// ttlUnits maps the units of a TTL term onto their duration.
+var ttlUnits = map[string]time.Duration{
+	"s":      time.Second,
+	"sec":    time.Second,
+	"second": time.Second,
+	"m":      time.Minute,
+	"min":    time.Minute,
+	"minute": time.Minute,
+	"h":      time.Hour,
+	"hour":   time.Hour,
+	"d":      24 * time.Hour,
+	"day":    24 * time.Hour,
+	"w":      7 * 24 * time.Hour,
+	"week":   7 * 24 * time.Hour,
+}
+
+// parseTTL parses a TTL made of one or more terms, such as "7d", "168h",
+// "1d12h" or "90 days".
+func parseTTL(ttl string) (time.Duration, error) {
+	rest := strings.TrimSpace(ttl)
+	if rest == "" {
+		return 0, fmt.Errorf("%q is not a TTL such as \"7d\" or \"168h\"", ttl)
+	}
+	var total time.Duration
+	for rest != "" {
+		match := ttlTermRegex.FindStringSubmatch(rest)
+		if match == nil {
+			return 0, fmt.Errorf("%q is not a TTL such as \"7d\" or \"168h\"", ttl)
+		}
+		count, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		unit := match[2]
+		if len(unit) > 1 {
+			unit = strings.TrimSuffix(unit, "s")
+		}
+		total += time.Duration(count) * ttlUnits[unit]
+		rest = strings.TrimSpace(rest[len(match[0]):])
+	}
+	return total, nil
+}
+
+// ttlType is a string type holding a TTL. Values which parse to the same
+// duration are semantically equal, so "7d" does not differ from "168h".
+type ttlType struct {
+	basetypes.StringType
+}
+
+func (t ttlType) Equal(o attr.Type) bool {
+	other, ok := o.(ttlType)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t ttlType) String() string {
+	return "ttlType"
+}
+
+func (t ttlType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return ttlValue{StringValue: in}, nil
+}
+
+func (t ttlType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+	stringValuable, diags := t.ValueFromString(ctx, stringValue)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unexpected error converting StringValue to StringValuable: %v", diags)
+	}
+	return stringValuable, nil
+}
+
+func (t ttlType) ValueType(_ context.Context) attr.Value {
+	return ttlValue{}
+}
+
+// ttlValue is a value of ttlType.
+type ttlValue struct {
+	basetypes.StringValue
+}
+
+func (v ttlValue) Equal(o attr.Value) bool {
+	other, ok := o.(ttlValue)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v ttlValue) Type(_ context.Context) attr.Type {
+	return ttlType{}
+}
+
+// StringSemanticEquals reports whether both TTLs parse to the same duration.
+func (v ttlValue) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	newValue, ok := newValuable.(ttlValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T but got value type %T. Please report this issue to the provider developers.", v, newValuable),
+		)
+		return false, diags
+	}
+	prior, err := parseTTL(v.ValueString())
+	if err != nil {
+		return false, nil
+	}
+	current, err := parseTTL(newValue.ValueString())
+	if err != nil {
+		return false, nil
+	}
+	return prior == current, nil
+}
+
+// ttlNull returns a null TTL.
+func ttlNull() ttlValue {
+	return ttlValue{StringValue: basetypes.NewStringNull()}
+}
+
+// ttlString returns a known TTL.
+func ttlString(value string) ttlValue {
+	return ttlValue{StringValue: basetypes.NewStringValue(value)}
+}
@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestParseTTL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		ttl     string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"7d", 7 * 24 * time.Hour, false},
+		{"168h", 168 * time.Hour, false},
+		{"1d12h", 36 * time.Hour, false},
+		{"90 days", 90 * 24 * time.Hour, false},
+		{"1 day", 24 * time.Hour, false},
+		{"2w", 14 * 24 * time.Hour, false},
+		{"30m", 30 * time.Minute, false},
+		{"45 secs", 45 * time.Second, false},
+		{"1h 30m", 90 * time.Minute, false},
+		{"", 0, true},
+		{"7", 0, true},
+		{"3 months", 0, true},
+		{"d7", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ttl, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseTTL(tt.ttl)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseTTL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseTTL() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTTLSemanticEquals(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	tests := []struct {
+		prior string
+		new   string
+		want  bool
+	}{
+		{"7d", "168h", true},
+		{"90 days", "90d", true},
+		{"7d", "8d", false},
+		{"invalid", "invalid", false},
+	}
+	for _, tt := range tests {
+		got, diags := ttlString(tt.prior).StringSemanticEquals(ctx, ttlString(tt.new))
+		if diags.HasError() {
+			t.Fatalf("StringSemanticEquals() diagnostics = %v", diags)
+		}
+		if got != tt.want {
+			t.Errorf("StringSemanticEquals(%q, %q) = %v, want %v", tt.prior, tt.new, got, tt.want)
+		}
+	}
+
+	_, diags := ttlString("7d").StringSemanticEquals(ctx, types.StringValue("7d"))
+	if !diags.HasError() {
+		t.Error("StringSemanticEquals() with a plain string succeeded, want error")
+	}
+}
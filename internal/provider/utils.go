@@ -3,7 +3,12 @@ package provider
 import (
 	"fmt"
 	"log"
+	"sort"
+	"strings"
 	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 func retry[T any](attempts int, sleep time.Duration, f func() (T, error)) (result T, err error) {
@@ -20,3 +25,65 @@ func retry[T any](attempts int, sleep time.Duration, f func() (T, error)) (resul
 	}
 	return result, fmt.Errorf("after %d attempts, last error: %s", attempts, err)
 }
+
+// setStrings returns the known elements of a set of strings in sorted order.
+func setStrings(set types.Set) []string {
+	var values []string
+	for _, element := range set.Elements() {
+		value, ok := element.(types.String)
+		if !ok || value.IsNull() || value.IsUnknown() {
+			continue
+		}
+		values = append(values, value.ValueString())
+	}
+	sort.Strings(values)
+	return values
+}
+
+// joinSet serialises a set of strings into the comma delimited format used by KMI.
+func joinSet(set types.Set) string {
+	return strings.Join(setStrings(set), ",")
+}
+
+// splitCommaList parses a comma delimited KMI value, dropping empty and duplicate entries.
+func splitCommaList(value string) []string {
+	values := []string{}
+	seen := map[string]bool{}
+	for _, element := range strings.Split(value, ",") {
+		element = strings.TrimSpace(element)
+		if element != "" && !seen[element] {
+			seen[element] = true
+			values = append(values, element)
+		}
+	}
+	return values
+}
+
+// splitToSet parses a comma delimited KMI value into a set of strings.
+func splitToSet(value string) types.Set {
+	return stringsToSet(splitCommaList(value))
+}
+
+// stringsToSet converts values into a set of strings.
+func stringsToSet(values []string) types.Set {
+	elements := []attr.Value{}
+	for _, value := range values {
+		elements = append(elements, types.StringValue(value))
+	}
+	return types.SetValueMust(types.StringType, elements)
+}
+
+// setDifference returns the known elements of a which are not in b, in sorted order.
+func setDifference(a types.Set, b types.Set) []string {
+	inB := map[string]bool{}
+	for _, value := range setStrings(b) {
+		inB[value] = true
+	}
+	var difference []string
+	for _, value := range setStrings(a) {
+		if !inB[value] {
+			difference = append(difference, value)
+		}
+	}
+	return difference
+}
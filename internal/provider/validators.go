@@ -0,0 +1,447 @@
+package provider
+
+import (
+	"context"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	// kmiNameRegex matches the names KMI accepts for collections, definitions,
+	// groups, engines, templates and workloads.
+	kmiNameRegex = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.\-]*$`)
+	// kmiLowerNameRegex is the stricter form required by azure_sp definitions.
+	kmiLowerNameRegex = regexp.MustCompile(`^[a-z0-9_\-]+$`)
+	// kubernetesNamespaceRegex is a RFC 1123 DNS label.
+	kubernetesNamespaceRegex = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+	// kubernetesServiceAccountRegex is a RFC 1123 DNS subdomain.
+	kubernetesServiceAccountRegex = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
+	// linodeRegionRegex matches Linode region IDs such as us-iad or in-bom-2.
+	linodeRegionRegex = regexp.MustCompile(`^[a-z]{2}-[a-z]+(-[0-9]+)?$`)
+	// kmiPeriodRegex matches KMI period strings such as "3 months" or "30 days".
+	kmiPeriodRegex = regexp.MustCompile(`^[0-9]+ ?(seconds?|minutes?|hours?|days?|weeks?|months?|years?)$`)
+	// dnsLabelRegex matches a single label of a DNS name.
+	dnsLabelRegex = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9\-]{0,61}[A-Za-z0-9])?$`)
+	// dnsGlobLabelRegex matches a DNS label in which '*' is a glob character.
+	dnsGlobLabelRegex = regexp.MustCompile(`^[A-Za-z0-9*]([A-Za-z0-9*\-]{0,61}[A-Za-z0-9*])?$`)
+)
+
+var (
+	_ validator.String = stringRegexValidator{}
+	_ validator.String = stringOneOfValidator{}
+	_ validator.Int64  = int64BetweenValidator{}
+	_ validator.Map    = reservedKeysValidator{}
+	_ validator.Set    = setElementsValidator{}
+	_ validator.String = ttlValidator{}
+	_ validator.String = certificateRequestValidator{}
+)
+
+// stringRegexValidator checks that a string matches a pattern and has a length
+// within the given bounds.
+type stringRegexValidator struct {
+	regex       *regexp.Regexp
+	minLength   int
+	maxLength   int
+	description string
+}
+
+func (v stringRegexValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("value must be %s between %d and %d characters long", v.description, v.minLength, v.maxLength)
+}
+
+func (v stringRegexValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v stringRegexValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	if len(value) < v.minLength || len(value) > v.maxLength || !v.regex.MatchString(value) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Attribute Value",
+			fmt.Sprintf("Attribute %s %s, got: %q", req.Path, v.Description(ctx), value),
+		)
+	}
+}
+
+// kmiNameValidator validates collection, definition, group, engine, template and workload names.
+func kmiNameValidator() validator.String {
+	return stringRegexValidator{
+		regex:       kmiNameRegex,
+		minLength:   1,
+		maxLength:   128,
+		description: "a KMI name made of letters, digits, '_', '.' and '-'",
+	}
+}
+
+// kubernetesNamespaceValidator validates Kubernetes namespaces.
+func kubernetesNamespaceValidator() validator.String {
+	return stringRegexValidator{
+		regex:       kubernetesNamespaceRegex,
+		minLength:   1,
+		maxLength:   63,
+		description: "a RFC 1123 DNS label",
+	}
+}
+
+// kubernetesServiceAccountValidator validates Kubernetes service account names.
+func kubernetesServiceAccountValidator() validator.String {
+	return stringRegexValidator{
+		regex:       kubernetesServiceAccountRegex,
+		minLength:   1,
+		maxLength:   253,
+		description: "a RFC 1123 DNS subdomain",
+	}
+}
+
+// linodeRegionValidator validates Linode region IDs.
+func linodeRegionValidator() validator.String {
+	return stringRegexValidator{
+		regex:       linodeRegionRegex,
+		minLength:   1,
+		maxLength:   32,
+		description: "a Linode region ID such as us-iad",
+	}
+}
+
+// kmiPeriodValidator validates KMI period strings used by expire_period and refresh_period.
+func kmiPeriodValidator() validator.String {
+	return stringRegexValidator{
+		regex:       kmiPeriodRegex,
+		minLength:   1,
+		maxLength:   32,
+		description: "a KMI period such as \"30 days\" or \"3 months\"",
+	}
+}
+
+// reservedKeysValidator rejects map keys that are managed through dedicated attributes.
+type reservedKeysValidator struct {
+	keys     []string
+	prefixes []string
+}
+
+func (v reservedKeysValidator) Description(_ context.Context) string {
+	reserved := append(append([]string{}, v.keys...), v.prefixes...)
+	return fmt.Sprintf("keys must not be one of the options managed by dedicated attributes: %s", strings.Join(reserved, ", "))
+}
+
+func (v reservedKeysValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v reservedKeysValidator) ValidateMap(ctx context.Context, req validator.MapRequest, resp *validator.MapResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	for key := range req.ConfigValue.Elements() {
+		if v.reserved(key) {
+			resp.Diagnostics.AddAttributeError(
+				req.Path.AtMapKey(key),
+				"Invalid Attribute Value",
+				fmt.Sprintf("Option %q is managed by a dedicated attribute and cannot be set in %s", key, req.Path),
+			)
+		}
+	}
+}
+
+func (v reservedKeysValidator) reserved(key string) bool {
+	for _, k := range v.keys {
+		if key == k {
+			return true
+		}
+	}
+	for _, prefix := range v.prefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// reservedKeysMapValidator validates extra_options maps of a definition type block.
+func reservedKeysMapValidator(keys []string, prefixes []string) validator.Map {
+	return reservedKeysValidator{keys: keys, prefixes: prefixes}
+}
+
+// stringOneOfValidator checks that a string is one of a fixed set of values.
+type stringOneOfValidator struct {
+	values []string
+}
+
+func (v stringOneOfValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("value must be one of: %s", strings.Join(v.values, ", "))
+}
+
+func (v stringOneOfValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v stringOneOfValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	for _, allowed := range v.values {
+		if value == allowed {
+			return
+		}
+	}
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Invalid Attribute Value",
+		fmt.Sprintf("Attribute %s %s, got: %q", req.Path, v.Description(ctx), value),
+	)
+}
+
+// stringOneOf validates that a string is one of the given values.
+func stringOneOf(values ...string) validator.String {
+	return stringOneOfValidator{values: values}
+}
+
+// ttlValidator checks that a string is a TTL such as "7d" or "168h".
+type ttlValidator struct{}
+
+func (v ttlValidator) Description(_ context.Context) string {
+	return "value must be a TTL such as \"7d\", \"168h\" or \"1d12h\""
+}
+
+func (v ttlValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v ttlValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	if _, err := parseTTL(value); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Attribute Value",
+			fmt.Sprintf("Attribute %s %s, got: %q", req.Path, v.Description(ctx), value),
+		)
+	}
+}
+
+// certificateRequestValidator checks that a string holds a PEM encoded
+// certificate signing request.
+type certificateRequestValidator struct{}
+
+func (v certificateRequestValidator) Description(_ context.Context) string {
+	return "value must be a PEM encoded CERTIFICATE REQUEST"
+}
+
+func (v certificateRequestValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v certificateRequestValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	block, _ := pem.Decode([]byte(strings.TrimSpace(req.ConfigValue.ValueString())))
+	if block == nil || (block.Type != "CERTIFICATE REQUEST" && block.Type != "NEW CERTIFICATE REQUEST") {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Attribute Value",
+			fmt.Sprintf("Attribute %s %s", req.Path, v.Description(ctx)),
+		)
+	}
+}
+
+// int64BetweenValidator checks that a number is within an inclusive range.
+type int64BetweenValidator struct {
+	minValue int64
+	maxValue int64
+}
+
+func (v int64BetweenValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("value must be between %d and %d", v.minValue, v.maxValue)
+}
+
+func (v int64BetweenValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v int64BetweenValidator) ValidateInt64(ctx context.Context, req validator.Int64Request, resp *validator.Int64Response) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueInt64()
+	if value < v.minValue || value > v.maxValue {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Attribute Value",
+			fmt.Sprintf("Attribute %s %s, got: %d", req.Path, v.Description(ctx), value),
+		)
+	}
+}
+
+// int64Between validates that a number is between minValue and maxValue inclusive.
+func int64Between(minValue int64, maxValue int64) validator.Int64 {
+	return int64BetweenValidator{minValue: minValue, maxValue: maxValue}
+}
+
+// kmiLowerNameValidator validates the lower case names required by azure_sp definitions.
+func kmiLowerNameValidator() validator.String {
+	return stringRegexValidator{
+		regex:       kmiLowerNameRegex,
+		minLength:   1,
+		maxLength:   128,
+		description: "a lower case KMI name made of letters, digits, '_' and '-'",
+	}
+}
+
+// setElementsValidator checks every known element of a set of strings.
+type setElementsValidator struct {
+	validate    func(string) error
+	description string
+}
+
+func (v setElementsValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("each element must be %s", v.description)
+}
+
+func (v setElementsValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v setElementsValidator) ValidateSet(ctx context.Context, req validator.SetRequest, resp *validator.SetResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	for _, element := range req.ConfigValue.Elements() {
+		value, ok := element.(types.String)
+		if !ok || value.IsNull() || value.IsUnknown() {
+			continue
+		}
+		if err := v.validate(value.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				req.Path.AtSetValue(value),
+				"Invalid Attribute Value",
+				fmt.Sprintf("Attribute %s %s: %s", req.Path, v.Description(ctx), err),
+			)
+		}
+	}
+}
+
+// dnsNamesValidator validates a set of DNS names. With glob, '*' may be used as a
+// glob character anywhere in a label, otherwise only a leading "*." wildcard is accepted.
+func dnsNamesValidator(glob bool) validator.Set {
+	description := "a DNS name, optionally starting with a \"*.\" wildcard"
+	if glob {
+		description = "a DNS name using '*' as a glob character"
+	}
+	return setElementsValidator{
+		validate:    func(name string) error { return validateDNSName(name, glob) },
+		description: description,
+	}
+}
+
+// urisValidator validates a set of URIs. With glob, '*' is accepted as a glob character.
+func urisValidator(glob bool) validator.Set {
+	return setElementsValidator{
+		validate:    func(uri string) error { return validateURI(uri, glob) },
+		description: "an absolute URI",
+	}
+}
+
+// kmiNamesValidator validates a set of KMI names, such as collection names.
+func kmiNamesValidator() validator.Set {
+	return setElementsValidator{
+		validate: func(name string) error {
+			if len(name) > 128 || !kmiNameRegex.MatchString(name) {
+				return fmt.Errorf("%q is not a KMI name made of letters, digits, '_', '.' and '-'", name)
+			}
+			return nil
+		},
+		description: "a KMI name made of letters, digits, '_', '.' and '-'",
+	}
+}
+
+// setOneOfValidator validates that every element of a set is one of values.
+func setOneOfValidator(values ...string) validator.Set {
+	return setElementsValidator{
+		validate: func(value string) error {
+			for _, allowed := range values {
+				if value == allowed {
+					return nil
+				}
+			}
+			return fmt.Errorf("%q is not one of: %s", value, strings.Join(values, ", "))
+		},
+		description: "one of: " + strings.Join(values, ", "),
+	}
+}
+
+// ipsOrCIDRsValidator validates a set of IP addresses and CIDR ranges.
+func ipsOrCIDRsValidator() validator.Set {
+	return setElementsValidator{
+		validate:    validateIPOrCIDR,
+		description: "an IP address or CIDR range",
+	}
+}
+
+func validateDNSName(name string, glob bool) error {
+	if glob && name == "*" {
+		return nil
+	}
+	if len(name) == 0 || len(name) > 253 {
+		return fmt.Errorf("%q must be between 1 and 253 characters long", name)
+	}
+	labels := strings.Split(strings.TrimSuffix(name, "."), ".")
+	for i, label := range labels {
+		switch {
+		case glob && dnsGlobLabelRegex.MatchString(label):
+		case !glob && i == 0 && label == "*" && len(labels) > 1:
+		case dnsLabelRegex.MatchString(label):
+		default:
+			return fmt.Errorf("%q has an invalid label %q", name, label)
+		}
+	}
+	return nil
+}
+
+func validateURI(uri string, glob bool) error {
+	if glob && uri == "*" {
+		return nil
+	}
+	if strings.ContainsAny(uri, ", ") {
+		return fmt.Errorf("%q must not contain commas or spaces", uri)
+	}
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return err
+	}
+	if parsed.Scheme == "" || (parsed.Host == "" && parsed.Opaque == "" && parsed.Path == "") {
+		return fmt.Errorf("%q is not an absolute URI", uri)
+	}
+	return nil
+}
+
+func validateIPOrCIDR(value string) error {
+	if net.ParseIP(value) != nil {
+		return nil
+	}
+	if _, _, err := net.ParseCIDR(value); err == nil {
+		return nil
+	}
+	return fmt.Errorf("%q is not an IP address or CIDR range", value)
+}
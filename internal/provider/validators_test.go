@@ -0,0 +1,158 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestStringRegexValidators(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		validator validator.String
+		value     types.String
+		wantError bool
+	}{
+		{"kmi name", kmiNameValidator(), types.StringValue("PIM_SECRETS"), false},
+		{"kmi name with dash and dot", kmiNameValidator(), types.StringValue("pi-qa.webapp-spa"), false},
+		{"kmi name with space", kmiNameValidator(), types.StringValue("PIM SECRETS"), true},
+		{"kmi name with slash", kmiNameValidator(), types.StringValue("PIM/SECRETS"), true},
+		{"kmi name empty", kmiNameValidator(), types.StringValue(""), true},
+		{"null is skipped", kmiNameValidator(), types.StringNull(), false},
+		{"unknown is skipped", kmiNameValidator(), types.StringUnknown(), false},
+		{"namespace", kubernetesNamespaceValidator(), types.StringValue("app-1"), false},
+		{"namespace upper case", kubernetesNamespaceValidator(), types.StringValue("App"), true},
+		{"namespace with dot", kubernetesNamespaceValidator(), types.StringValue("app.team"), true},
+		{"service account", kubernetesServiceAccountValidator(), types.StringValue("kmi-sa.team"), false},
+		{"service account trailing dash", kubernetesServiceAccountValidator(), types.StringValue("kmi-sa-"), true},
+		{"linode region", linodeRegionValidator(), types.StringValue("us-iad"), false},
+		{"linode region with index", linodeRegionValidator(), types.StringValue("in-bom-2"), false},
+		{"linode region upper case", linodeRegionValidator(), types.StringValue("US-IAD"), true},
+		{"period months", kmiPeriodValidator(), types.StringValue("3 months"), false},
+		{"period single day", kmiPeriodValidator(), types.StringValue("1 day"), false},
+		{"period without unit", kmiPeriodValidator(), types.StringValue("30"), true},
+		{"period unknown unit", kmiPeriodValidator(), types.StringValue("3 fortnights"), true},
+		{"ttl", ttlValidator{}, types.StringValue("1d12h"), false},
+		{"ttl without unit", ttlValidator{}, types.StringValue("36"), true},
+		{"certificate request", certificateRequestValidator{}, types.StringValue("-----BEGIN CERTIFICATE REQUEST-----\nYWJj\n-----END CERTIFICATE REQUEST-----\n"), false},
+		{"certificate instead of request", certificateRequestValidator{}, types.StringValue("-----BEGIN CERTIFICATE-----\nYWJj\n-----END CERTIFICATE-----\n"), true},
+		{"request not pem", certificateRequestValidator{}, types.StringValue("csr"), true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := validator.StringRequest{
+				Path:        path.Root("test"),
+				ConfigValue: tt.value,
+			}
+			resp := &validator.StringResponse{}
+			tt.validator.ValidateString(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() != tt.wantError {
+				t.Errorf("ValidateString(%s) error = %v, want %v", tt.value, resp.Diagnostics, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestReservedKeysValidator(t *testing.T) {
+	t.Parallel()
+
+	v := reservedKeysMapValidator(sslCertOptionNames, sslCertOptionPrefixes)
+	tests := []struct {
+		name      string
+		key       string
+		wantError bool
+	}{
+		{"free option", "key_usage", false},
+		{"dedicated option", "cn", true},
+		{"acl option", "signacl:PIM_SECRETS", true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := validator.MapRequest{
+				Path: path.Root("ssl_cert").AtName("extra_options"),
+				ConfigValue: types.MapValueMust(types.StringType, map[string]attr.Value{
+					tt.key: types.StringValue("value"),
+				}),
+			}
+			resp := &validator.MapResponse{}
+			v.ValidateMap(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() != tt.wantError {
+				t.Errorf("ValidateMap(%s) error = %v, want %v", tt.key, resp.Diagnostics, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestSetElementsValidators(t *testing.T) {
+	t.Parallel()
+
+	set := func(values ...string) types.Set {
+		elements := make([]attr.Value, 0, len(values))
+		for _, value := range values {
+			elements = append(elements, types.StringValue(value))
+		}
+		return types.SetValueMust(types.StringType, elements)
+	}
+
+	tests := []struct {
+		name      string
+		validator validator.Set
+		value     types.Set
+		wantError bool
+	}{
+		{"dns names", dnsNamesValidator(false), set("example.com", "*.example.com", "a-1.example.com"), false},
+		{"dns name glob in label", dnsNamesValidator(false), set("web-*.example.com"), true},
+		{"dns name wildcard only", dnsNamesValidator(false), set("*"), true},
+		{"dns name with comma", dnsNamesValidator(false), set("a.com,b.com"), true},
+		{"dns name leading dash", dnsNamesValidator(false), set("-a.example.com"), true},
+		{"dns name empty", dnsNamesValidator(false), set(""), true},
+		{"dns glob names", dnsNamesValidator(true), set("*", "web-*.example.com", "*.*.example.com"), false},
+		{"dns glob name with space", dnsNamesValidator(true), set("web *.example.com"), true},
+		{"uris", urisValidator(false), set("spiffe://example.com/ns/default", "https://example.com"), false},
+		{"uri without scheme", urisValidator(false), set("example.com/path"), true},
+		{"uri with comma", urisValidator(false), set("https://a.com,https://b.com"), true},
+		{"uri glob", urisValidator(true), set("*", "spiffe://example.com/*"), false},
+		{"ips and cidrs", ipsOrCIDRsValidator(), set("10.0.0.1", "10.0.0.0/8", "2001:db8::/32"), false},
+		{"invalid ip", ipsOrCIDRsValidator(), set("10.0.0.256"), true},
+		{"kmi names", kmiNamesValidator(), set("web_certs", "PIM.SECRETS-1"), false},
+		{"kmi name with slash", kmiNamesValidator(), set("web/certs"), true},
+		{"key types", setOneOfValidator(templateKeyTypes...), set("rsa:4096", "ed25519"), false},
+		{"unknown key type", setOneOfValidator(templateKeyTypes...), set("rsa:1024"), true},
+		{"null is skipped", dnsNamesValidator(false), types.SetNull(types.StringType), false},
+		{"unknown is skipped", dnsNamesValidator(false), types.SetUnknown(types.StringType), false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := validator.SetRequest{
+				Path:        path.Root("test"),
+				ConfigValue: tt.value,
+			}
+			resp := &validator.SetResponse{}
+			tt.validator.ValidateSet(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() != tt.wantError {
+				t.Errorf("ValidateSet(%s) error = %v, want %v", tt.value, resp.Diagnostics, tt.wantError)
+			}
+		})
+	}
+}
@@ -6,8 +6,13 @@ import (
 	"terraform-provider-kmi/internal/kmi"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -40,9 +45,27 @@ type WorkloadVMResourceModel struct {
 	Engine      types.String `tfsdk:"engine"`
 	Region      types.String `tfsdk:"region"`
 	LinodeLabel types.String `tfsdk:"linode_label"`
+	InstanceID  types.String `tfsdk:"instance_id"`
+	Tags        types.Set    `tfsdk:"tags"`
+	Azure       *AzureVM     `tfsdk:"azure"`
+	GCP         *GCPVM       `tfsdk:"gcp"`
 	LastUpdated types.String `tfsdk:"last_updated"`
 }
 
+// AzureVM maps the Azure virtual machine identity of a workload.
+type AzureVM struct {
+	SubscriptionID types.String `tfsdk:"subscription_id"`
+	ResourceGroup  types.String `tfsdk:"resource_group"`
+	VMName         types.String `tfsdk:"vm_name"`
+}
+
+// GCPVM maps the GCP compute instance identity of a workload.
+type GCPVM struct {
+	Project      types.String `tfsdk:"project"`
+	Zone         types.String `tfsdk:"zone"`
+	InstanceName types.String `tfsdk:"instance_name"`
+}
+
 // Schema defines the schema for the resource.
 func (r *workloadResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
@@ -50,21 +73,96 @@ func (r *workloadResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 
 			"name": schema.StringAttribute{
 				Required: true,
+				Validators: []validator.String{
+					kmiNameValidator(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"account": schema.StringAttribute{
 				Required: true,
+				Validators: []validator.String{
+					kmiNameValidator(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"engine": schema.StringAttribute{
 				Required: true,
+				Validators: []validator.String{
+					kmiNameValidator(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"region": schema.StringAttribute{
 				Required: true,
 			},
 			"linode_label": schema.StringAttribute{
-				Optional: true,
+				Optional:    true,
+				Description: "The Linode label of the VMs matched by the workload. Can be '*' to match all labels ",
+			},
+			"instance_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "The cloud instance ID of the VM matched by the workload ",
+			},
+			"tags": schema.SetAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "The instance tags a VM must carry to be matched by the workload ",
+			},
+			"azure": schema.SingleNestedAttribute{
+				Optional:    true,
+				Description: "The Azure VM identity matched by the workload ",
+				Attributes: map[string]schema.Attribute{
+					"subscription_id": schema.StringAttribute{
+						Required:    true,
+						Description: "The Azure subscription ID of the VM ",
+					},
+					"resource_group": schema.StringAttribute{
+						Optional:    true,
+						Description: "The Azure resource group of the VM ",
+					},
+					"vm_name": schema.StringAttribute{
+						Optional:    true,
+						Description: "The Azure VM name. Can use '*' as a glob character ",
+					},
+				},
+			},
+			"gcp": schema.SingleNestedAttribute{
+				Optional:    true,
+				Description: "The GCP compute instance identity matched by the workload ",
+				Attributes: map[string]schema.Attribute{
+					"project": schema.StringAttribute{
+						Required:    true,
+						Description: "The GCP project ID of the instance ",
+					},
+					"zone": schema.StringAttribute{
+						Optional:    true,
+						Description: "The GCP zone of the instance ",
+					},
+					"instance_name": schema.StringAttribute{
+						Optional:    true,
+						Description: "The GCP instance name. Can use '*' as a glob character ",
+					},
+				},
 			},
 			"last_updated": schema.StringAttribute{
-				Computed: true,
+				Computed:    true,
+				Description: "The last time the workload was updated. ",
+				PlanModifiers: []planmodifier.String{
+					useStateForUnknownUnlessChanged(
+						path.Root("region"),
+						path.Root("linode_label"),
+						path.Root("instance_id"),
+						path.Root("tags"),
+						path.Root("azure"),
+						path.Root("gcp"),
+					),
+				},
 			},
 		},
 	}
@@ -80,17 +178,9 @@ func (r *workloadResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
-	kmiworkload := &kmi.Workload{
-		Projection: plan.Name.ValueString(),
-		Region: struct {
-			Text   string "xml:\",chardata\""
-			Source string "xml:\"source,attr,omitempty\""
-		}{
-			Text: plan.Region.ValueString(),
-		},
-		LinodeLabel: &kmi.LinodeLabel{
-			Text: plan.LinodeLabel.ValueString(),
-		},
+	kmiworkload := workloadPayload(ctx, plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 	tflog.Info(ctx, "Create workload payload %v\n")
 
@@ -111,9 +201,7 @@ func (r *workloadResource) Create(ctx context.Context, req resource.CreateReques
 		)
 		return
 	}
-	plan.Name = types.StringValue(kmiworkloadfromservice.Projection)
-	plan.Region = types.StringValue(kmiworkloadfromservice.Region.Text)
-	plan.LinodeLabel = types.StringValue(kmiworkloadfromservice.LinodeLabel.Text)
+	plan.setWorkload(ctx, kmiworkloadfromservice, &resp.Diagnostics)
 
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
@@ -142,9 +230,7 @@ func (r *workloadResource) Read(ctx context.Context, req resource.ReadRequest, r
 		)
 		return
 	}
-	state.Name = types.StringValue(kmiworkloadfromservice.Projection)
-	state.Region = types.StringValue(kmiworkloadfromservice.Region.Text)
-	state.LinodeLabel = types.StringValue(kmiworkloadfromservice.LinodeLabel.Text)
+	state.setWorkload(ctx, kmiworkloadfromservice, &resp.Diagnostics)
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -164,17 +250,9 @@ func (r *workloadResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
-	kmiworkload := &kmi.Workload{
-		Projection: plan.Name.ValueString(),
-		Region: struct {
-			Text   string "xml:\",chardata\""
-			Source string "xml:\"source,attr,omitempty\""
-		}{
-			Text: plan.Region.ValueString(),
-		},
-		LinodeLabel: &kmi.LinodeLabel{
-			Text: plan.LinodeLabel.ValueString(),
-		},
+	kmiworkload := workloadPayload(ctx, plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 	tflog.Info(ctx, "Create workload payload %v\n")
 
@@ -195,11 +273,13 @@ func (r *workloadResource) Update(ctx context.Context, req resource.UpdateReques
 		)
 		return
 	}
-	plan.Name = types.StringValue(kmiworkloadfromservice.Projection)
-	plan.Region = types.StringValue(kmiworkloadfromservice.Region.Text)
-	plan.LinodeLabel = types.StringValue(kmiworkloadfromservice.LinodeLabel.Text)
+	plan.setWorkload(ctx, kmiworkloadfromservice, &resp.Diagnostics)
 
-	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+	// KMI keeps no modified timestamp, so last_updated only moves when the plan
+	// changes the workload.
+	if plan.LastUpdated.IsUnknown() {
+		plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+	}
 
 	resp.State.Set(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -248,3 +328,90 @@ func (r *workloadResource) Configure(_ context.Context, req resource.ConfigureRe
 
 	r.client = client
 }
+
+// workloadPayload builds the KMI workload request from the planned selectors.
+func workloadPayload(ctx context.Context, plan WorkloadVMResourceModel, diags *diag.Diagnostics) *kmi.Workload {
+	kmiworkload := &kmi.Workload{
+		Projection: plan.Name.ValueString(),
+		InstanceID: workloadSelector(plan.InstanceID),
+	}
+	kmiworkload.Region.Text = plan.Region.ValueString()
+	if !plan.LinodeLabel.IsNull() {
+		kmiworkload.LinodeLabel = &kmi.LinodeLabel{
+			Text: plan.LinodeLabel.ValueString(),
+		}
+	}
+	if !plan.Tags.IsNull() {
+		var tags []string
+		diags.Append(plan.Tags.ElementsAs(ctx, &tags, false)...)
+		for _, tag := range tags {
+			kmiworkload.Tags = append(kmiworkload.Tags, kmi.WorkloadSelector{Text: tag})
+		}
+	}
+	if plan.Azure != nil {
+		kmiworkload.AzureSubscriptionID = workloadSelector(plan.Azure.SubscriptionID)
+		kmiworkload.AzureResourceGroup = workloadSelector(plan.Azure.ResourceGroup)
+		kmiworkload.AzureVMName = workloadSelector(plan.Azure.VMName)
+	}
+	if plan.GCP != nil {
+		kmiworkload.GCPProject = workloadSelector(plan.GCP.Project)
+		kmiworkload.GCPZone = workloadSelector(plan.GCP.Zone)
+		kmiworkload.GCPInstanceName = workloadSelector(plan.GCP.InstanceName)
+	}
+	return kmiworkload
+}
+
+func workloadSelector(value types.String) *kmi.WorkloadSelector {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+	return &kmi.WorkloadSelector{Text: value.ValueString()}
+}
+
+func workloadSelectorValue(selector *kmi.WorkloadSelector) types.String {
+	if selector == nil {
+		return types.StringNull()
+	}
+	return types.StringValue(selector.Text)
+}
+
+// setWorkload maps the workload returned by KMI onto the resource model, which
+// holds the plan or prior state.
+func (m *WorkloadVMResourceModel) setWorkload(ctx context.Context, workload *kmi.Workload, diags *diag.Diagnostics) {
+	m.Name = types.StringValue(workload.Projection)
+	m.Region = types.StringValue(workload.Region.Text)
+	m.LinodeLabel = types.StringNull()
+	if workload.LinodeLabel != nil {
+		m.LinodeLabel = types.StringValue(workload.LinodeLabel.Text)
+	}
+	m.InstanceID = workloadSelectorValue(workload.InstanceID)
+
+	// KMI returns no tags for an empty set, so an empty set in the model is kept.
+	var tags []string
+	for _, tag := range workload.Tags {
+		tags = append(tags, tag.Text)
+	}
+	if len(tags) > 0 || !m.Tags.IsNull() {
+		m.Tags = stringsToSet(tags)
+	} else {
+		m.Tags = types.SetNull(types.StringType)
+	}
+
+	m.Azure = nil
+	if workload.AzureSubscriptionID != nil {
+		m.Azure = &AzureVM{
+			SubscriptionID: workloadSelectorValue(workload.AzureSubscriptionID),
+			ResourceGroup:  workloadSelectorValue(workload.AzureResourceGroup),
+			VMName:         workloadSelectorValue(workload.AzureVMName),
+		}
+	}
+
+	m.GCP = nil
+	if workload.GCPProject != nil {
+		m.GCP = &GCPVM{
+			Project:      workloadSelectorValue(workload.GCPProject),
+			Zone:         workloadSelectorValue(workload.GCPZone),
+			InstanceName: workloadSelectorValue(workload.GCPInstanceName),
+		}
+	}
+}
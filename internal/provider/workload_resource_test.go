@@ -0,0 +1,153 @@
+package provider
+
+import (
+	"context"
+	"encoding/xml"
+	"reflect"
+	"terraform-provider-kmi/internal/kmi"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestWorkloadResourceSchema(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	schemaRequest := fwresource.SchemaRequest{}
+	schemaResponse := &fwresource.SchemaResponse{}
+
+	NewWorkloadResource().Schema(ctx, schemaRequest, schemaResponse)
+
+	if schemaResponse.Diagnostics.HasError() {
+		t.Fatalf("Schema method diagnostics: %+v", schemaResponse.Diagnostics)
+	}
+
+	diagnostics := schemaResponse.Schema.ValidateImplementation(ctx)
+
+	if diagnostics.HasError() {
+		t.Fatalf("Schema validation diagnostics: %+v", diagnostics)
+	}
+}
+
+func TestWorkloadRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		model WorkloadVMResourceModel
+	}{
+		{
+			name: "linode",
+			model: WorkloadVMResourceModel{
+				Name:        types.StringValue("vm"),
+				Region:      types.StringValue("us-east"),
+				LinodeLabel: types.StringValue("*"),
+				InstanceID:  types.StringNull(),
+				Tags:        types.SetNull(types.StringType),
+			},
+		},
+		{
+			name: "instance id and tags",
+			model: WorkloadVMResourceModel{
+				Name:        types.StringValue("vm"),
+				Region:      types.StringValue("us-east"),
+				LinodeLabel: types.StringNull(),
+				InstanceID:  types.StringValue("12345"),
+				Tags:        stringsToSet([]string{"web", "prod"}),
+			},
+		},
+		{
+			name: "empty tags",
+			model: WorkloadVMResourceModel{
+				Name:        types.StringValue("vm"),
+				Region:      types.StringValue("us-east"),
+				LinodeLabel: types.StringNull(),
+				InstanceID:  types.StringNull(),
+				Tags:        stringsToSet(nil),
+			},
+		},
+		{
+			name: "azure",
+			model: WorkloadVMResourceModel{
+				Name:        types.StringValue("vm"),
+				Region:      types.StringValue("eastus"),
+				LinodeLabel: types.StringNull(),
+				InstanceID:  types.StringNull(),
+				Tags:        types.SetNull(types.StringType),
+				Azure: &AzureVM{
+					SubscriptionID: types.StringValue("0000-1111"),
+					ResourceGroup:  types.StringNull(),
+					VMName:         types.StringValue("web-*"),
+				},
+			},
+		},
+		{
+			name: "gcp",
+			model: WorkloadVMResourceModel{
+				Name:        types.StringValue("vm"),
+				Region:      types.StringValue("us-east1"),
+				LinodeLabel: types.StringNull(),
+				InstanceID:  types.StringNull(),
+				Tags:        types.SetNull(types.StringType),
+				GCP: &GCPVM{
+					Project:      types.StringValue("my-project"),
+					Zone:         types.StringValue("us-east1-b"),
+					InstanceName: types.StringNull(),
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			var diags diag.Diagnostics
+			payload := workloadPayload(ctx, tt.model, &diags)
+			data, err := xml.Marshal(payload)
+			if err != nil {
+				t.Fatalf("xml.Marshal() error = %v", err)
+			}
+			var workload kmi.Workload
+			if err := xml.Unmarshal(data, &workload); err != nil {
+				t.Fatalf("xml.Unmarshal() error = %v", err)
+			}
+
+			got := tt.model
+			got.setWorkload(ctx, &workload, &diags)
+			if diags.HasError() {
+				t.Fatalf("diagnostics = %v", diags)
+			}
+			if !got.Tags.Equal(tt.model.Tags) {
+				t.Errorf("setWorkload() tags = %v, want %v", got.Tags, tt.model.Tags)
+			}
+			got.Tags = tt.model.Tags
+			if !reflect.DeepEqual(got, tt.model) {
+				t.Errorf("setWorkload() = %+v, want %+v", got, tt.model)
+			}
+		})
+	}
+}
+
+func TestSetWorkloadTags(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	var diags diag.Diagnostics
+	model := WorkloadVMResourceModel{Tags: types.SetNull(types.StringType)}
+	model.setWorkload(ctx, &kmi.Workload{Tags: []kmi.WorkloadSelector{{Text: "web"}}}, &diags)
+	if want := stringsToSet([]string{"web"}); !model.Tags.Equal(want) {
+		t.Errorf("setWorkload() tags = %v, want %v", model.Tags, want)
+	}
+
+	// Tags removed outside Terraform read back as an empty set.
+	model.setWorkload(ctx, &kmi.Workload{}, &diags)
+	if want := stringsToSet(nil); !model.Tags.Equal(want) {
+		t.Errorf("setWorkload() tags = %v, want %v", model.Tags, want)
+	}
+}